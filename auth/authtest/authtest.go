@@ -0,0 +1,101 @@
+// Package authtest provides utilities for testing application code,
+// that authenticate requests using go-guardian strategies,
+// without setting up real authentication backends.
+package authtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// ErrExhausted is returned by MockStrategy Authenticate method,
+// when all sequence results consumed.
+var ErrExhausted = errors.New("authtest: Mock results sequence exhausted")
+
+// MockResult represents a single Authenticate outcome.
+type MockResult struct {
+	Info auth.Info
+	Err  error
+}
+
+// Call represents a recorded Authenticate call.
+type Call struct {
+	Request *http.Request
+	// Header is a copy of the request header at call time.
+	Header http.Header
+}
+
+// MockStrategy implements auth.Strategy, and return preconfigured results,
+// while recording every Authenticate call.
+// MockStrategy is safe for concurrent use.
+type MockStrategy struct {
+	mu      sync.Mutex
+	results []MockResult
+	repeat  bool
+	calls   []Call
+}
+
+// Authenticate record the call and return the next configured result.
+func (m *MockStrategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	call := Call{Request: r}
+	if r != nil {
+		call.Header = r.Header.Clone()
+	}
+	m.calls = append(m.calls, call)
+
+	if m.repeat {
+		return m.results[0].Info, m.results[0].Err
+	}
+
+	i := len(m.calls) - 1
+	if i >= len(m.results) {
+		return nil, ErrExhausted
+	}
+
+	return m.results[i].Info, m.results[i].Err
+}
+
+// Calls return the recorded calls in order.
+func (m *MockStrategy) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount return the number of Authenticate calls.
+func (m *MockStrategy) CallCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.calls)
+}
+
+// Always return MockStrategy that always authenticate requests as info.
+func Always(info auth.Info) *MockStrategy {
+	return &MockStrategy{
+		results: []MockResult{{Info: info}},
+		repeat:  true,
+	}
+}
+
+// AlwaysFail return MockStrategy that always fail requests with err.
+func AlwaysFail(err error) *MockStrategy {
+	return &MockStrategy{
+		results: []MockResult{{Err: err}},
+		repeat:  true,
+	}
+}
+
+// Sequence return MockStrategy that return results in order, one per call,
+// and ErrExhausted once consumed.
+func Sequence(results ...MockResult) *MockStrategy {
+	return &MockStrategy{
+		results: results,
+	}
+}
@@ -0,0 +1,87 @@
+package authtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/middleware"
+)
+
+func TestAlways(t *testing.T) {
+	info := auth.NewUserInfo("test", "1", nil, nil)
+	m := Always(info)
+
+	for i := 0; i < 3; i++ {
+		got, err := m.Authenticate(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, info, got)
+	}
+
+	assert.Equal(t, 3, m.CallCount())
+}
+
+func TestAlwaysFail(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	m := AlwaysFail(errInvalid)
+
+	got, err := m.Authenticate(context.TODO(), nil)
+	assert.Nil(t, got)
+	assert.Equal(t, errInvalid, err)
+}
+
+func TestSequence(t *testing.T) {
+	info := auth.NewUserInfo("test", "1", nil, nil)
+	errInvalid := errors.New("invalid")
+	m := Sequence(MockResult{Err: errInvalid}, MockResult{Info: info})
+
+	_, err := m.Authenticate(context.TODO(), nil)
+	assert.Equal(t, errInvalid, err)
+
+	got, err := m.Authenticate(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, info, got)
+
+	_, err = m.Authenticate(context.TODO(), nil)
+	assert.Equal(t, ErrExhausted, err)
+}
+
+func TestCalls(t *testing.T) {
+	m := Always(auth.NewUserInfo("test", "1", nil, nil))
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	_, _ = m.Authenticate(r.Context(), r)
+
+	// header recorded at call time.
+	r.Header.Set("Authorization", "Bearer other")
+
+	calls := m.Calls()
+	assert.Len(t, calls, 1)
+	assert.Equal(t, r, calls[0].Request)
+	assert.Equal(t, "Bearer token", calls[0].Header.Get("Authorization"))
+}
+
+func TestHandlerReceiveInfo(t *testing.T) {
+	info := auth.NewUserInfo("test", "1", []string{"admin"}, nil)
+	m := Always(info)
+
+	var got auth.Info
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = auth.User(r)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-ID", "1")
+	middleware.Authenticate(m)(handler).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, info, got)
+	assert.Equal(t, 1, m.CallCount())
+	assert.Equal(t, "1", m.Calls()[0].Header.Get("X-Request-ID"))
+}
@@ -0,0 +1,29 @@
+package authtest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/authtest"
+	"github.com/shaj13/go-guardian/v2/middleware"
+)
+
+func ExampleAlways() {
+	// handler under test.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello "+auth.User(r).GetUserName())
+	})
+
+	strategy := authtest.Always(auth.NewUserInfo("example", "1", nil, nil))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	middleware.Authenticate(strategy)(handler).ServeHTTP(w, r)
+
+	fmt.Println(w.Body.String(), strategy.CallCount())
+
+	// Output:
+	// hello example 1
+}
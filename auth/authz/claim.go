@@ -0,0 +1,69 @@
+package authz
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// ClaimPredicate reports whether the claim values satisfy a condition.
+type ClaimPredicate func(values []string) bool
+
+// ClaimEquals return predicate reports whether the claim has the single value v.
+func ClaimEquals(v string) ClaimPredicate {
+	return func(values []string) bool {
+		return len(values) == 1 && values[0] == v
+	}
+}
+
+// ClaimContains return predicate reports whether any of the claim values equal to v,
+// commonly used with multi-valued claims e.g groups.
+func ClaimContains(v string) ClaimPredicate {
+	return func(values []string) bool {
+		for _, value := range values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ClaimMatchesRegexp return predicate reports whether any of the claim values matches re.
+func ClaimMatchesRegexp(re *regexp.Regexp) ClaimPredicate {
+	return func(values []string) bool {
+		for _, value := range values {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ClaimCheck return HTTP middleware, that reply with 403 Forbidden,
+// when the authenticated user stored in the request context lacks the claim,
+// or the claim values does not satisfy the predicate.
+// Claims read from the user info extensions, populated by the strategy info builder or claims resolver.
+//
+// ClaimCheck must be placed after the authentication middleware.
+func ClaimCheck(key string, predicate ClaimPredicate) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !checkClaim(auth.User(r), key, predicate) {
+				code := http.StatusForbidden
+				http.Error(w, http.StatusText(code), code)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func checkClaim(info auth.Info, key string, predicate ClaimPredicate) bool {
+	if info == nil || !info.GetExtensions().Has(key) {
+		return false
+	}
+	return predicate(info.GetExtensions().Values(key))
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestClaimCheck(t *testing.T) {
+	table := []struct {
+		name      string
+		info      auth.Info
+		key       string
+		predicate ClaimPredicate
+		expected  int
+	}{
+		{
+			name:      "it allow request when claim equals",
+			info:      userWith("department", "engineering"),
+			key:       "department",
+			predicate: ClaimEquals("engineering"),
+			expected:  http.StatusOK,
+		},
+		{
+			name:      "it deny request when claim not equals",
+			info:      userWith("department", "sales"),
+			key:       "department",
+			predicate: ClaimEquals("engineering"),
+			expected:  http.StatusForbidden,
+		},
+		{
+			name:      "it deny request when claim equals one of multiple values",
+			info:      userWith("department", "sales", "engineering"),
+			key:       "department",
+			predicate: ClaimEquals("engineering"),
+			expected:  http.StatusForbidden,
+		},
+		{
+			name:      "it allow request when claim contains value",
+			info:      userWith("teams", "sales", "engineering"),
+			key:       "teams",
+			predicate: ClaimContains("engineering"),
+			expected:  http.StatusOK,
+		},
+		{
+			name:      "it deny request when claim does not contain value",
+			info:      userWith("teams", "sales"),
+			key:       "teams",
+			predicate: ClaimContains("engineering"),
+			expected:  http.StatusForbidden,
+		},
+		{
+			name:      "it allow request when claim matches regexp",
+			info:      userWith("email", "jane@example.com"),
+			key:       "email",
+			predicate: ClaimMatchesRegexp(regexp.MustCompile(`@example\.com$`)),
+			expected:  http.StatusOK,
+		},
+		{
+			name:      "it deny request when claim does not match regexp",
+			info:      userWith("email", "jane@evil.com"),
+			key:       "email",
+			predicate: ClaimMatchesRegexp(regexp.MustCompile(`@example\.com$`)),
+			expected:  http.StatusForbidden,
+		},
+		{
+			name:      "it deny request when claim missing",
+			info:      auth.NewUserInfo("jane", "1", nil, nil),
+			key:       "department",
+			predicate: func([]string) bool { return true },
+			expected:  http.StatusForbidden,
+		},
+		{
+			name:      "it deny request when user missing",
+			key:       "department",
+			predicate: func([]string) bool { return true },
+			expected:  http.StatusForbidden,
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.info != nil {
+				r = auth.RequestWithUser(tt.info, r)
+			}
+			ClaimCheck(tt.key, tt.predicate)(next).ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
+
+func userWith(key string, values ...string) auth.Info {
+	return auth.NewUserInfo("jane", "1", nil, auth.Extensions{key: values})
+}
@@ -0,0 +1,46 @@
+package authz_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/authz"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func ExampleRBAC() {
+	rbac := authz.NewRBAC()
+	rbac.Allow("admin", authz.AnyMethod, "/books/*")
+	rbac.Allow("reader", http.MethodGet, "/books/*")
+
+	info := auth.NewDefaultUser("example", "1", []string{"reader"}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/books/1", nil)
+	fmt.Println(rbac.Authorize(info, r))
+
+	r = httptest.NewRequest(http.MethodDelete, "/books/1", nil)
+	fmt.Println(rbac.Authorize(info, r))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleRequireScope() {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := authz.RequireScope("read:users")(next)
+
+	info := auth.NewDefaultUser("example", "1", nil, nil)
+	// typically populated by the token strategies from the token scope claim.
+	token.WithNamedScopes(info, "read:users", "write:billing")
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	h.ServeHTTP(w, auth.RequestWithUser(info, r))
+	fmt.Println(w.Code)
+
+	// Output:
+	// 200
+}
@@ -0,0 +1,169 @@
+// Package opa provides authorization using Open Policy Agent (OPA),
+// by querying an OPA instance REST API data endpoint,
+// with an input document describing the request and the authenticated user.
+// See https://www.openpolicyagent.org/docs/latest/rest-api/#get-a-document-with-input.
+package opa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// Input represents the OPA input document.
+type Input struct {
+	Method string   `json:"method"`
+	Path   string   `json:"path"`
+	User   string   `json:"user"`
+	ID     string   `json:"id"`
+	Groups []string `json:"groups"`
+	Scopes []string `json:"scopes"`
+}
+
+type request struct {
+	Input Input `json:"input"`
+}
+
+type response struct {
+	Result *bool `json:"result"`
+}
+
+type opaError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e opaError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Authorizer authorize requests by evaluating a boolean OPA policy decision,
+// e.g "data.httpapi.authz.allow", and caches the decisions by policy and input.
+type Authorizer struct {
+	requester *internal.Requester
+	cache     auth.Cache
+	ttl       time.Duration
+	policy    string
+}
+
+// Authorize reports whether the policy allow the user to perform the request.
+// An undefined policy decision treated as deny.
+func (a *Authorizer) Authorize(ctx context.Context, info auth.Info, r *http.Request) (bool, error) {
+	in := Input{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Groups: []string{},
+		Scopes: []string{},
+	}
+
+	if info != nil {
+		in.User = info.GetUserName()
+		in.ID = info.GetID()
+		in.Groups = append(in.Groups, info.GetGroups()...)
+		in.Scopes = append(in.Scopes, token.GetNamedScopes(info)...)
+	}
+
+	body := request{Input: in}
+	key, err := a.key(body)
+	if err != nil {
+		return false, err
+	}
+
+	if v, ok := a.cache.Load(key); ok {
+		allow, ok := v.(bool)
+		if !ok {
+			return false, auth.NewTypeError("authz/opa:", false, v)
+		}
+		return allow, nil
+	}
+
+	res := new(response)
+	operr := new(opaError)
+
+	//nolint:bodyclose
+	resp, err := a.requester.Do(ctx, body, res, operr)
+
+	switch {
+	case err != nil:
+		return false, fmt.Errorf("authz/opa: %w", err)
+	case resp.StatusCode != http.StatusOK && len(operr.Code) > 0:
+		return false, fmt.Errorf("authz/opa: %w", operr)
+	case resp.StatusCode != http.StatusOK:
+		return false, fmt.Errorf("authz/opa: OPA returned %v status code", resp.StatusCode)
+	}
+
+	allow := res.Result != nil && *res.Result
+	a.cache.StoreWithTTL(key, allow, a.ttl)
+
+	return allow, nil
+}
+
+func (a *Authorizer) key(body request) (string, error) {
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(a.policy))
+	h.Write([]byte{0})
+	h.Write(buf)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// New return new Authorizer, evaluates the policy at the OPA instance addr,
+// policy is the data document path e.g "httpapi/authz/allow" or "data.httpapi.authz.allow".
+func New(addr, policy string, c auth.Cache, opts ...auth.Option) *Authorizer {
+	policy = strings.Trim(strings.Replace(strings.TrimPrefix(policy, "data."), ".", "/", -1), "/")
+
+	r := internal.NewRequester(addr)
+	r.Endpoint = "/v1/data/" + policy
+	r.KeepUnmarshalling = true
+	r.SetHeader("Content-Type", "application/json")
+
+	a := new(Authorizer)
+	a.requester = r
+	a.cache = c
+	a.ttl = time.Minute
+	a.policy = policy
+
+	for _, opt := range opts {
+		opt.Apply(a)
+		opt.Apply(a.requester)
+	}
+
+	return a
+}
+
+// Middleware return HTTP handler, that authorize requests using the authenticated user
+// stored in the request context, and reply with 403 Forbidden when the request denied,
+// or 500 Internal Server Error when the policy evaluation fails.
+//
+// Middleware must be placed after the authentication middleware.
+func Middleware(a *Authorizer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allow, err := a.Authorize(r.Context(), auth.User(r), r)
+
+		code := http.StatusForbidden
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		if !allow {
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,168 @@
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func TestAuthorize(t *testing.T) {
+	calls := 0
+	srv := mockOPA(t, &calls)
+	defer srv.Close()
+
+	admin := auth.NewUserInfo("alice", "1", []string{"admin"}, nil)
+	reader := auth.NewUserInfo("bob", "2", nil, nil)
+	token.WithNamedScopes(reader, "read")
+
+	table := []struct {
+		name        string
+		policy      string
+		info        auth.Info
+		method      string
+		allow       bool
+		expectedErr string
+	}{
+		{
+			name:   "it allow request when policy allow",
+			policy: "data.httpapi.authz.allow",
+			info:   admin,
+			method: "DELETE",
+			allow:  true,
+		},
+		{
+			name:   "it allow request based on user scopes",
+			policy: "httpapi/authz/allow",
+			info:   reader,
+			method: "GET",
+			allow:  true,
+		},
+		{
+			name:   "it deny request when policy deny",
+			policy: "httpapi/authz/allow",
+			info:   reader,
+			method: "DELETE",
+		},
+		{
+			name:   "it deny request when user nil",
+			policy: "httpapi/authz/allow",
+			method: "GET",
+		},
+		{
+			name:   "it deny request when policy decision undefined",
+			policy: "httpapi/undefined",
+			info:   admin,
+			method: "GET",
+		},
+		{
+			name:        "it return error when opa return error",
+			policy:      "httpapi/invalid",
+			info:        admin,
+			method:      "GET",
+			expectedErr: "internal_error: policy evaluation failed",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			a := New(srv.URL, tt.policy, libcache.LRU.New(0))
+			r, _ := http.NewRequest(tt.method, "/books", nil)
+			allow, err := a.Authorize(context.TODO(), tt.info, r)
+
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.allow, allow)
+		})
+	}
+}
+
+func TestAuthorizeCache(t *testing.T) {
+	calls := 0
+	srv := mockOPA(t, &calls)
+	defer srv.Close()
+
+	a := New(srv.URL, "httpapi/authz/allow", libcache.LRU.New(0))
+	admin := auth.NewUserInfo("alice", "1", []string{"admin"}, nil)
+
+	for _, p := range []string{"/books", "/books", "/authors"} {
+		r, _ := http.NewRequest("GET", p, nil)
+		allow, err := a.Authorize(context.TODO(), admin, r)
+		assert.NoError(t, err)
+		assert.True(t, allow)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestMiddleware(t *testing.T) {
+	srv := mockOPA(t, new(int))
+	defer srv.Close()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	table := []struct {
+		policy string
+		info   auth.Info
+		code   int
+	}{
+		{policy: "httpapi/authz/allow", info: auth.NewUserInfo("alice", "1", []string{"admin"}, nil), code: http.StatusOK},
+		{policy: "httpapi/authz/allow", info: auth.NewUserInfo("bob", "2", nil, nil), code: http.StatusForbidden},
+		{policy: "httpapi/invalid", info: auth.NewUserInfo("alice", "1", nil, nil), code: http.StatusInternalServerError},
+	}
+
+	for _, tt := range table {
+		a := New(srv.URL, tt.policy, libcache.LRU.New(0))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/books", nil)
+		Middleware(a, next).ServeHTTP(w, auth.RequestWithUser(tt.info, r))
+		assert.Equal(t, tt.code, w.Code)
+	}
+}
+
+// mockOPA mock OPA data api serving httpapi.authz.allow policy,
+// which allow admins, and GET requests for users with read scope.
+func mockOPA(tb testing.TB, calls *int) *httptest.Server {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		body := new(request)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			tb.Error(err)
+		}
+
+		switch r.URL.Path {
+		case "/v1/data/httpapi/authz/allow":
+			in := body.Input
+			allow := contains(in.Groups, "admin") || (in.Method == "GET" && contains(in.Scopes, "read"))
+			_ = json.NewEncoder(w).Encode(map[string]bool{"result": allow})
+		case "/v1/data/httpapi/invalid":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":"internal_error","message":"policy evaluation failed"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(h))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
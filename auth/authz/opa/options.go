@@ -0,0 +1,36 @@
+package opa
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+// SetHTTPClient sets underlying http client.
+func SetHTTPClient(c *http.Client) auth.Option {
+	return internal.SetRequesterHTTPClient(c)
+}
+
+// SetTLSConfig sets underlying http client tls.
+func SetTLSConfig(tls *tls.Config) auth.Option {
+	return internal.SetRequesterTLSConfig(tls)
+}
+
+// SetBearerToken sets the token to authenticate to OPA,
+// when OPA started with --authentication=token.
+func SetBearerToken(token string) auth.Option {
+	return internal.SetRequesterBearerToken(token)
+}
+
+// SetTTL sets the policy decisions cache ttl.
+// Default: 1 Minute
+func SetTTL(ttl time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if a, ok := v.(*Authorizer); ok {
+			a.ttl = ttl
+		}
+	})
+}
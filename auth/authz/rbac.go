@@ -0,0 +1,90 @@
+// Package authz provides authorization primitives,
+// to decide what an authenticated user can do, based on the auth.Info
+// produced by the authentication strategies.
+package authz
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// AnyMethod matches any HTTP method in RBAC rules.
+const AnyMethod = "*"
+
+type rule struct {
+	method  string
+	pattern string
+}
+
+func (r rule) match(method, p string) bool {
+	if r.method != AnyMethod && !strings.EqualFold(r.method, method) {
+		return false
+	}
+
+	ok, err := path.Match(r.pattern, p)
+	return err == nil && ok
+}
+
+// RBAC implements role-based access control,
+// by mapping role names to the permitted HTTP method and path pattern pairs.
+// User roles read from auth.Info groups.
+type RBAC struct {
+	mu    sync.RWMutex
+	roles map[string][]rule
+}
+
+// Allow grants the role access to requests with the given method and path pattern.
+// Method can be AnyMethod to match all methods,
+// and pattern uses path.Match syntax e.g "/users/*".
+func (rb *RBAC) Allow(role, method, pattern string) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.roles[role] = append(rb.roles[role], rule{method: method, pattern: pattern})
+}
+
+// Authorize reports whether any of the user roles allow the request.
+// Authorize reports false for nil info.
+func (rb *RBAC) Authorize(info auth.Info, r *http.Request) bool {
+	if info == nil {
+		return false
+	}
+
+	rb.mu.RLock()
+	defer rb.mu.RUnlock()
+
+	for _, role := range info.GetGroups() {
+		for _, rule := range rb.roles[role] {
+			if rule.match(r.Method, r.URL.Path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// NewRBAC return new empty RBAC, that deny all requests.
+func NewRBAC() *RBAC {
+	return &RBAC{
+		roles: make(map[string][]rule),
+	}
+}
+
+// RBACMiddleware return HTTP handler, that authorize requests using the authenticated user
+// stored in the request context, and reply with 403 Forbidden when the request denied.
+//
+// RBACMiddleware must be placed after the authentication middleware.
+func RBACMiddleware(rbac *RBAC, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !rbac.Authorize(auth.User(r), r) {
+			code := http.StatusForbidden
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
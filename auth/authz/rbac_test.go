@@ -0,0 +1,139 @@
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestRBACAuthorize(t *testing.T) {
+	rbac := NewRBAC()
+	rbac.Allow("admin", AnyMethod, "/*")
+	rbac.Allow("admin", AnyMethod, "/users/*")
+	rbac.Allow("viewer", http.MethodGet, "/users/*")
+	rbac.Allow("editor", http.MethodPut, "/users/*/profile")
+
+	table := []struct {
+		name     string
+		info     auth.Info
+		method   string
+		path     string
+		expected bool
+	}{
+		{
+			name:     "it allow role with matching rule",
+			info:     auth.NewDefaultUser("test", "1", []string{"viewer"}, nil),
+			method:   http.MethodGet,
+			path:     "/users/1",
+			expected: true,
+		},
+		{
+			name:     "it deny role without matching method",
+			info:     auth.NewDefaultUser("test", "1", []string{"viewer"}, nil),
+			method:   http.MethodDelete,
+			path:     "/users/1",
+			expected: false,
+		},
+		{
+			name:     "it deny unknown role",
+			info:     auth.NewDefaultUser("test", "1", []string{"guest"}, nil),
+			method:   http.MethodGet,
+			path:     "/users/1",
+			expected: false,
+		},
+		{
+			name:     "it allow wildcard method",
+			info:     auth.NewDefaultUser("test", "1", []string{"admin"}, nil),
+			method:   http.MethodDelete,
+			path:     "/users/1",
+			expected: true,
+		},
+		{
+			name:     "it allow glob path pattern",
+			info:     auth.NewDefaultUser("test", "1", []string{"editor"}, nil),
+			method:   http.MethodPut,
+			path:     "/users/1/profile",
+			expected: true,
+		},
+		{
+			name:     "it deny path not matching glob segments",
+			info:     auth.NewDefaultUser("test", "1", []string{"editor"}, nil),
+			method:   http.MethodPut,
+			path:     "/users/1/settings",
+			expected: false,
+		},
+		{
+			name:     "it allow any of user roles",
+			info:     auth.NewDefaultUser("test", "1", []string{"guest", "viewer"}, nil),
+			method:   http.MethodGet,
+			path:     "/users/1",
+			expected: true,
+		},
+		{
+			name:     "it deny anonymous user with empty groups",
+			info:     auth.NewDefaultUser("anonymous", "", nil, nil),
+			method:   http.MethodGet,
+			path:     "/users/1",
+			expected: false,
+		},
+		{
+			name:     "it deny nil info",
+			method:   http.MethodGet,
+			path:     "/users/1",
+			expected: false,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.expected, rbac.Authorize(tt.info, r))
+		})
+	}
+}
+
+func TestRBACMiddleware(t *testing.T) {
+	rbac := NewRBAC()
+	rbac.Allow("viewer", http.MethodGet, "/*")
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := RBACMiddleware(rbac, next)
+
+	table := []struct {
+		name string
+		info auth.Info
+		code int
+	}{
+		{
+			name: "it call next handler when allowed",
+			info: auth.NewDefaultUser("test", "1", []string{"viewer"}, nil),
+			code: http.StatusOK,
+		},
+		{
+			name: "it return 403 when denied",
+			info: auth.NewDefaultUser("test", "1", []string{"guest"}, nil),
+			code: http.StatusForbidden,
+		},
+		{
+			name: "it return 403 for unauthenticated request",
+			code: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/resource", nil)
+			if tt.info != nil {
+				r = auth.RequestWithUser(tt.info, r)
+			}
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// RequireScope return HTTP middleware, that reply with 403 Forbidden,
+// when the authenticated user stored in the request context lacks the scope.
+// Scopes read from the user info named scopes,
+// populated by the token based strategies e.g jwt, introspection, from the token scope claim.
+//
+// RequireScope must be placed after the authentication middleware.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !token.HasScope(auth.User(r), scope) {
+				code := http.StatusForbidden
+				http.Error(w, http.StatusText(code), code)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
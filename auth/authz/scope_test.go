@@ -0,0 +1,66 @@
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func TestRequireScope(t *testing.T) {
+	table := []struct {
+		name     string
+		scopes   []string
+		noUser   bool
+		expected int
+	}{
+		{
+			name:     "it allow request when scope present",
+			scopes:   []string{"read:users"},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "it allow request when scope present among multiple scopes",
+			scopes:   []string{"write:billing", "read:users", "admin"},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "it deny request when scope absent",
+			scopes:   []string{"write:billing"},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "it deny request when user has no scopes",
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "it deny request when user missing",
+			noUser:   true,
+			expected: http.StatusForbidden,
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := RequireScope("read:users")(next)
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			if !tt.noUser {
+				info := auth.NewDefaultUser("test", "1", nil, nil)
+				if len(tt.scopes) > 0 {
+					token.WithNamedScopes(info, tt.scopes...)
+				}
+				r = auth.RequestWithUser(info, r)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tt.expected, w.Code)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import "time"
 
 // Cache type describes the requirements for authentication strategies,
 // that cache the authentication decisions.
+// libcache caches satisfy Cache, and could be resized at runtime using their Resize method,
+// e.g during a memory pressure event, without dropping the entries that fit.
 type Cache interface {
 	// Load returns key value.
 	Load(key interface{}) (interface{}, bool)
@@ -0,0 +1,171 @@
+// Package client provides helpers for HTTP clients of go-guardian protected servers.
+package client
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ServerAuthorizationHeader is the response header where the server signature set.
+	ServerAuthorizationHeader = "Server-Authorization"
+	// ServerAuthorizationScheme is the Server-Authorization header scheme.
+	ServerAuthorizationScheme = "Signature"
+	// NonceHeader is the request header carrying the client nonce,
+	// echoed in the server signature to bind the response to the request.
+	NonceHeader = "X-Request-Nonce"
+)
+
+var (
+	// ErrMissingServerSignature is returned by VerifyResponse,
+	// when the response missing or has malformed Server-Authorization header.
+	ErrMissingServerSignature = errors.New("auth/client: Response missing server signature")
+
+	// ErrInvalidServerSignature is returned by VerifyResponse,
+	// when the response signature does not match the response body.
+	ErrInvalidServerSignature = errors.New("auth/client: Invalid server signature")
+
+	// ErrUnsupportedKey is returned by VerifyResponse,
+	// when the server key type not supported.
+	ErrUnsupportedKey = errors.New("auth/client: Unsupported server key")
+
+	// ErrMissingRequest is returned by VerifyResponse,
+	// when the response has no request to verify the signature binding.
+	ErrMissingRequest = errors.New("auth/client: Response missing request")
+)
+
+// SignatureBase return the content signed by the server,
+// binding the response status and body to the request method, target and nonce:
+//
+// 		status \n method \n target \n nonce \n hex(sha256(body))
+//
+func SignatureBase(status int, method, target, nonce string, body []byte) []byte {
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+
+	sum := sha256.Sum256(body)
+	return []byte(strconv.Itoa(status) + "\n" +
+		method + "\n" +
+		target + "\n" +
+		nonce + "\n" +
+		hex.EncodeToString(sum[:]))
+}
+
+// VerifyResponse verify the response Server-Authorization header,
+// set by the mutual middleware, against the response status, body and request using the server key.
+// The response Request must be set, as done by http.Client.
+// The key must be one of *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey (PKCS #1 v1.5),
+// or []byte as HMAC-SHA256 shared secret.
+// The response body read and replaced, so it can be read again by the caller.
+func VerifyResponse(resp *http.Response, key crypto.PublicKey) error {
+	h := resp.Header.Get(ServerAuthorizationHeader)
+	if !strings.HasPrefix(h, ServerAuthorizationScheme+" ") {
+		return ErrMissingServerSignature
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(h[len(ServerAuthorizationScheme)+1:]))
+	if err != nil {
+		return ErrMissingServerSignature
+	}
+
+	if resp.Request == nil || resp.Request.URL == nil {
+		return ErrMissingRequest
+	}
+
+	body := []byte{}
+	if resp.Body != nil {
+		b, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		body = b
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	r := resp.Request
+	base := SignatureBase(resp.StatusCode, r.Method, r.URL.RequestURI(), r.Header.Get(NonceHeader), body)
+	sum := sha256.Sum256(base)
+	ok := false
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		var rs struct{ R, S *big.Int }
+		if rest, err := asn1.Unmarshal(sig, &rs); err == nil && len(rest) == 0 {
+			ok = ecdsa.Verify(key, sum[:], rs.R, rs.S)
+		}
+	case ed25519.PublicKey:
+		ok = len(key) == ed25519.PublicKeySize && ed25519.Verify(key, sum[:], sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) == nil
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write(sum[:])
+		ok = hmac.Equal(mac.Sum(nil), sig)
+	default:
+		return ErrUnsupportedKey
+	}
+
+	if !ok {
+		return ErrInvalidServerSignature
+	}
+
+	return nil
+}
+
+// MutualTransport is an http.RoundTripper that verify every response server signature,
+// and fails the request when verification fails.
+// A random nonce set in the request X-Request-Nonce header when missing.
+type MutualTransport struct {
+	// Key is the server verification key, see VerifyResponse.
+	Key crypto.PublicKey
+	// Base is the underlying RoundTripper, Default http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MutualTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if len(r.Header.Get(NonceHeader)) == 0 {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		// RoundTripper must not modify the request.
+		r = r.Clone(r.Context())
+		r.Header.Set(NonceHeader, hex.EncodeToString(b))
+	}
+
+	resp, err := base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := VerifyResponse(resp, t.Key); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
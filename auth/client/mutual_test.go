@@ -0,0 +1,109 @@
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/client"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+	"github.com/shaj13/go-guardian/v2/middleware/mutual"
+)
+
+func TestVerifyResponse(t *testing.T) {
+	table := []struct {
+		name   string
+		header string
+		key    interface{}
+		err    error
+	}{
+		{
+			name: "it return error when header missing",
+			key:  []byte("secret"),
+			err:  client.ErrMissingServerSignature,
+		},
+		{
+			name:   "it return error when header scheme invalid",
+			header: "Bearer abc",
+			key:    []byte("secret"),
+			err:    client.ErrMissingServerSignature,
+		},
+		{
+			name:   "it return error when signature not base64",
+			header: "Signature !!",
+			key:    []byte("secret"),
+			err:    client.ErrMissingServerSignature,
+		},
+		{
+			name:   "it return error when key not supported",
+			header: "Signature YWJj",
+			key:    "secret",
+			err:    client.ErrUnsupportedKey,
+		},
+		{
+			name:   "it return error when request missing",
+			header: "Signature YWJj",
+			key:    []byte("secret"),
+			err:    client.ErrMissingRequest,
+		},
+		{
+			name:   "it return error when signature invalid",
+			header: "Signature YWJj",
+			key:    []byte("secret"),
+			err:    client.ErrInvalidServerSignature,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.err != client.ErrMissingRequest {
+				resp.Request = httptest.NewRequest("GET", "/", nil)
+			}
+			if len(tt.header) > 0 {
+				resp.Header.Set(client.ServerAuthorizationHeader, tt.header)
+			}
+			assert.Equal(t, tt.err, client.VerifyResponse(resp, tt.key))
+		})
+	}
+}
+
+func TestMutualTransport(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	strategy := token.NewStatic(map[string]auth.Info{
+		"token": auth.NewDefaultUser("user", "1", nil, nil),
+	})
+	nonces := make(chan string, 2)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces <- r.Header.Get(client.NonceHeader)
+		_, _ = w.Write([]byte("balance: 100"))
+	})
+	srv := httptest.NewServer(mutual.MutualAuth(key, strategy, next))
+	defer srv.Close()
+
+	do := func(c *http.Client) (*http.Response, error) {
+		r, _ := http.NewRequest("GET", srv.URL, nil)
+		r.Header.Set("Authorization", "Bearer token")
+		return c.Do(r)
+	}
+
+	resp, err := do(&http.Client{Transport: &client.MutualTransport{Key: &key.PublicKey}})
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "balance: 100", string(body))
+	assert.Len(t, <-nonces, 32)
+
+	_, err = do(&http.Client{Transport: &client.MutualTransport{Key: &other.PublicKey}})
+	assert.True(t, errors.Is(err, client.ErrInvalidServerSignature))
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
@@ -32,3 +33,18 @@ func TestUser(t *testing.T) {
 	}
 
 }
+
+func TestUserContextPropagation(t *testing.T) {
+	info := NewDefaultUser("test", "1", nil, nil)
+	r, _ := http.NewRequest("GET", "/", nil)
+	r = RequestWithUser(info, r)
+
+	// derived contexts and requests keep the user info.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	r = r.WithContext(context.WithValue(ctx, struct{}{}, "value"))
+
+	assert.Equal(t, info, User(r))
+	assert.Equal(t, info, UserFromCtx(r.Context()))
+	assert.Nil(t, UserFromCtx(context.Background()))
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"strings"
+
+	"github.com/shaj13/go-guardian/v2/auth/internal/flight"
+)
+
+type deduplicate struct {
+	Strategy
+	group flight.Group
+}
+
+func (d *deduplicate) Authenticate(ctx context.Context, r *http.Request) (Info, error) {
+	authz := r.Header.Get("Authorization")
+	if len(authz) == 0 {
+		return d.Strategy.Authenticate(ctx, r)
+	}
+
+	v, err := d.group.Do(deduplicateKey(r, authz), func() (interface{}, error) {
+		// detach the shared call from the first caller cancellation,
+		// while keeping its deadline to bound the call.
+		ctx, cancel := flight.Detach(ctx)
+		defer cancel()
+		return d.Strategy.Authenticate(ctx, r.WithContext(ctx))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := v.(Info)
+	if !ok {
+		return nil, NewTypeError("auth:", (*Info)(nil), v)
+	}
+
+	return info, nil
+}
+
+// deduplicateKey return the request identity,
+// the method, host, path, query, client certificate and Authorization header,
+// joined by new lines which none of them contain.
+func deduplicateKey(r *http.Request, authz string) string {
+	cert := ""
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		sum := sha256.Sum256(r.TLS.PeerCertificates[0].Raw)
+		cert = string(sum[:])
+	}
+
+	return strings.Join([]string{
+		r.Method,
+		r.Host,
+		r.URL.EscapedPath(),
+		r.URL.RawQuery,
+		cert,
+		authz,
+	}, "\n")
+}
+
+// Deduplicate return strategy that coalesce concurrent authentication of the same identity,
+// keyed on the request method, host, path, query, client certificate and raw Authorization header.
+// Concurrent callers block on the first in-flight call and share its result,
+// hence the wrapped strategy (and its cache) invoked once per key.
+// The shared call runs with the first caller request, and its context values and deadline,
+// but it's not canceled when the first caller canceled, and waiters block until it returns.
+// Requests without Authorization header passed directly to the wrapped strategy.
+//
+// Warning: concurrent requests with the same key share the first request result,
+// only wrap strategies that authenticate on the key parts solely,
+// and not on other headers (e.g a tenant header), cookies or the request body.
+func Deduplicate(s Strategy) Strategy {
+	return &deduplicate{Strategy: s}
+}
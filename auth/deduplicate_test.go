@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingStrategy struct {
+	calls   int32
+	release chan struct{}
+	err     error
+}
+
+func (c *countingStrategy) Authenticate(ctx context.Context, r *http.Request) (Info, error) {
+	atomic.AddInt32(&c.calls, 1)
+	<-c.release
+	if c.err != nil {
+		return nil, c.err
+	}
+	return NewUserInfo("test", "1", nil, nil), nil
+}
+
+func TestDeduplicate(t *testing.T) {
+	table := []struct {
+		name          string
+		header        string
+		distinctPaths bool
+		err           error
+		expectedCalls int32
+	}{
+		{
+			name:          "it coalesce concurrent calls of the same token",
+			header:        "Bearer token",
+			expectedCalls: 1,
+		},
+		{
+			name:          "it share the error of the in-flight call",
+			header:        "Bearer token",
+			err:           errors.New("invalid token"),
+			expectedCalls: 1,
+		},
+		{
+			name:          "it does not coalesce requests without authorization header",
+			expectedCalls: 50,
+		},
+		{
+			name:          "it does not coalesce requests of different paths",
+			header:        "Bearer token",
+			distinctPaths: true,
+			expectedCalls: 50,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 50
+			s := &countingStrategy{release: make(chan struct{}), err: tt.err}
+			d := Deduplicate(s)
+			wg := sync.WaitGroup{}
+			started := sync.WaitGroup{}
+
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				started.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					path := "/"
+					if tt.distinctPaths {
+						path += strconv.Itoa(i)
+					}
+					r, _ := http.NewRequest("GET", path, nil)
+					if len(tt.header) > 0 {
+						r.Header.Set("Authorization", tt.header)
+					}
+					started.Done()
+					info, err := d.Authenticate(r.Context(), r)
+					assert.Equal(t, tt.err, err)
+					if tt.err == nil {
+						assert.Equal(t, "test", info.GetUserName())
+					}
+				}(i)
+			}
+
+			started.Wait()
+			for atomic.LoadInt32(&s.calls) < tt.expectedCalls {
+				time.Sleep(time.Millisecond)
+			}
+			// give the goroutines a chance to join the in-flight call.
+			time.Sleep(time.Millisecond * 50)
+			close(s.release)
+			wg.Wait()
+
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(&s.calls))
+		})
+	}
+}
+
+func TestDeduplicateNilInfo(t *testing.T) {
+	s := strategyFunc(func(context.Context, *http.Request) (Info, error) {
+		return nil, nil
+	})
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+
+	info, err := Deduplicate(s).Authenticate(r.Context(), r)
+
+	assert.Nil(t, info)
+	assert.Error(t, err)
+}
+
+func TestDeduplicateFirstCallerCanceled(t *testing.T) {
+	release := make(chan struct{})
+	s := strategyFunc(func(ctx context.Context, r *http.Request) (Info, error) {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return NewUserInfo("test", "1", nil, nil), nil
+	})
+	d := Deduplicate(s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r = r.WithContext(ctx)
+
+	first := make(chan error)
+	go func() {
+		_, err := d.Authenticate(ctx, r)
+		first <- err
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	second := make(chan error)
+	go func() {
+		r2, _ := http.NewRequest("GET", "/", nil)
+		r2.Header.Set("Authorization", "Bearer token")
+		_, err := d.Authenticate(r2.Context(), r2)
+		second <- err
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	cancel()
+	close(release)
+
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-second)
+}
+
+type strategyFunc func(context.Context, *http.Request) (Info, error)
+
+func (fn strategyFunc) Authenticate(ctx context.Context, r *http.Request) (Info, error) {
+	return fn(ctx, r)
+}
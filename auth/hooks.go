@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"log"
+	"net/http"
+)
+
+// EventHook observes authentication events, commonly used for auditing and metrics.
+// Hooks called synchronously on the request path,
+// they must not block and must be safe for concurrent use.
+type EventHook interface {
+	// OnAuthenticated called after the request authenticated successfully.
+	OnAuthenticated(info Info, r *http.Request)
+	// OnAuthFailed called after the request authentication fails.
+	OnAuthFailed(err error, r *http.Request)
+	// OnCacheHit called when the cache key found in the strategy cache,
+	// key is a fingerprint of the cache key, and never the raw credential.
+	OnCacheHit(key string)
+	// OnCacheMiss called when the cache key not found in the strategy cache,
+	// key is a fingerprint of the cache key, and never the raw credential.
+	OnCacheMiss(key string)
+}
+
+// NopEventHook implements EventHook and does nothing,
+// it's meant to be embedded by hooks that observe a subset of events.
+type NopEventHook struct{}
+
+// OnAuthenticated implements EventHook.
+func (NopEventHook) OnAuthenticated(Info, *http.Request) {}
+
+// OnAuthFailed implements EventHook.
+func (NopEventHook) OnAuthFailed(error, *http.Request) {}
+
+// OnCacheHit implements EventHook.
+func (NopEventHook) OnCacheHit(string) {}
+
+// OnCacheMiss implements EventHook.
+func (NopEventHook) OnCacheMiss(string) {}
+
+// EventHooks implements EventHook and fans out events to hooks in order.
+// A panicking hook is recovered and logged, without affecting the next hooks,
+// or the authentication result.
+type EventHooks []EventHook
+
+// OnAuthenticated implements EventHook.
+func (hs EventHooks) OnAuthenticated(info Info, r *http.Request) {
+	hs.each(func(h EventHook) { h.OnAuthenticated(info, r) })
+}
+
+// OnAuthFailed implements EventHook.
+func (hs EventHooks) OnAuthFailed(err error, r *http.Request) {
+	hs.each(func(h EventHook) { h.OnAuthFailed(err, r) })
+}
+
+// OnCacheHit implements EventHook.
+func (hs EventHooks) OnCacheHit(key string) {
+	hs.each(func(h EventHook) { h.OnCacheHit(key) })
+}
+
+// OnCacheMiss implements EventHook.
+func (hs EventHooks) OnCacheMiss(key string) {
+	hs.each(func(h EventHook) { h.OnCacheMiss(key) })
+}
+
+func (hs EventHooks) each(fn func(EventHook)) {
+	for _, h := range hs {
+		call(h, fn)
+	}
+}
+
+func call(h EventHook, fn func(EventHook)) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("auth: EventHook %T panic: %v", h, r)
+		}
+	}()
+	fn(h)
+}
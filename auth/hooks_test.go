@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventHooks(t *testing.T) {
+	buf := new(bytes.Buffer)
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	events := []string{}
+	record := func(name string) recordHook {
+		return recordHook{name: name, events: &events}
+	}
+
+	hooks := EventHooks{record("first"), panicHook{}, record("second")}
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	hooks.OnAuthenticated(NewDefaultUser("test", "1", nil, nil), r)
+	hooks.OnAuthFailed(errors.New("failed"), r)
+	hooks.OnCacheHit("hit")
+	hooks.OnCacheMiss("miss")
+
+	assert.Equal(t, []string{
+		"first:authenticated:test",
+		"second:authenticated:test",
+		"first:failed:failed",
+		"second:failed:failed",
+		"first:hit:hit",
+		"second:hit:hit",
+		"first:miss:miss",
+		"second:miss:miss",
+	}, events)
+	assert.Contains(t, buf.String(), "auth: EventHook auth.panicHook panic: boom")
+}
+
+type recordHook struct {
+	name   string
+	events *[]string
+}
+
+func (h recordHook) OnAuthenticated(info Info, _ *http.Request) {
+	*h.events = append(*h.events, h.name+":authenticated:"+info.GetUserName())
+}
+
+func (h recordHook) OnAuthFailed(err error, _ *http.Request) {
+	*h.events = append(*h.events, h.name+":failed:"+err.Error())
+}
+
+func (h recordHook) OnCacheHit(key string) {
+	*h.events = append(*h.events, h.name+":hit:"+key)
+}
+
+func (h recordHook) OnCacheMiss(key string) {
+	*h.events = append(*h.events, h.name+":miss:"+key)
+}
+
+type panicHook struct{}
+
+func (panicHook) OnAuthenticated(Info, *http.Request) { panic("boom") }
+func (panicHook) OnAuthFailed(error, *http.Request)   { panic("boom") }
+func (panicHook) OnCacheHit(string)                   { panic("boom") }
+func (panicHook) OnCacheMiss(string)                  { panic("boom") }
@@ -93,3 +93,15 @@ func ParseJSONBody(key string, r *http.Request, err error) (string, error) {
 
 	return pin, nil
 }
+
+// ParseForm extract key value form HTTP request form-encoded body or return provided error.
+func ParseForm(key string, r *http.Request, err error) (string, error) {
+	value := r.PostFormValue(key)
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return "", err
+	}
+
+	return value, nil
+}
@@ -204,7 +204,7 @@ func SetRequesterAddress(addr string) auth.Option {
 func SetRequesterEndpoint(endpoint string) auth.Option {
 	return auth.OptionFunc(func(v interface{}) {
 		if r, ok := v.(*Requester); ok {
-			r.Addr = "/" + strings.TrimSuffix(endpoint, "/")
+			r.Endpoint = "/" + strings.Trim(endpoint, "/")
 		}
 	})
 }
@@ -0,0 +1,35 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/retry"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+func ExampleWithRetry() {
+	attempts := 0
+	strategy := basic.New(func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		attempts++
+		if attempts < 3 {
+			// mark backend error as transient.
+			return nil, retry.Retryable(errors.New("ldap: connection lost"))
+		}
+		return auth.NewDefaultUser(userName, "1", nil, nil), nil
+	})
+
+	s := retry.WithRetry(strategy, 3, time.Millisecond)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("admin", "admin")
+	info, err := s.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err, attempts)
+
+	// Output:
+	// admin <nil> 3
+}
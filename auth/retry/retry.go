@@ -0,0 +1,113 @@
+// Package retry provides a strategy wrapper that retries transient authentication backend errors,
+// (e.g network timeout, connection reset) with exponential back-off and jitter.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// RetryableError is implemented by errors that report whether the failed call can be retried.
+// Strategies and callbacks can implement it to mark their own errors.
+type RetryableError interface {
+	error
+	Retryable() bool
+}
+
+type retryable struct {
+	error
+}
+
+func (retryable) Retryable() bool { return true }
+
+func (r retryable) Unwrap() error { return r.error }
+
+// Retryable wraps err and marks it as retryable.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryable{err}
+}
+
+// IsTransient reports whether err is a transient error,
+// either marked as retryable, a network timeout, or a connection reset/refused.
+func IsTransient(err error) bool {
+	var re RetryableError
+	if errors.As(err, &re) {
+		return re.Retryable()
+	}
+
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+type strategy struct {
+	auth.Strategy
+	maxAttempts int
+	base        time.Duration
+}
+
+func (s strategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	var (
+		info auth.Info
+		err  error
+	)
+
+	for attempt := 0; attempt < s.maxAttempts; attempt++ {
+		if attempt > 0 {
+			t := time.NewTimer(s.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return nil, fmt.Errorf("retry: %w, last attempt error: %v", ctx.Err(), err)
+			case <-t.C:
+			}
+		}
+
+		info, err = s.Strategy.Authenticate(ctx, r)
+		if err == nil || !IsTransient(err) {
+			return info, err
+		}
+	}
+
+	return info, err
+}
+
+// backoff return base * 2^(attempt-1) with full jitter in its upper half.
+func (s strategy) backoff(attempt int) time.Duration {
+	d := s.base << uint(attempt-1)
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// WithRetry return strategy that retries the provided strategy,
+// up to max attempts on transient errors, with exponential back-off starting at base.
+// Non-transient errors (e.g invalid credentials) returned immediately.
+// When ctx done during back-off, the returned error wraps ctx.Err().
+func WithRetry(s auth.Strategy, maxAttempts int, base time.Duration) auth.Strategy {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return strategy{
+		Strategy:    s,
+		maxAttempts: maxAttempts,
+		base:        base,
+	}
+}
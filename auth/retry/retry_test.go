@@ -0,0 +1,129 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+type mockStrategy struct {
+	errs  []error
+	calls int
+}
+
+func (m *mockStrategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	m.calls++
+	if m.calls <= len(m.errs) {
+		return nil, m.errs[m.calls-1]
+	}
+	return auth.NewDefaultUser("test", "1", nil, nil), nil
+}
+
+func TestWithRetry(t *testing.T) {
+	transient := Retryable(errors.New("ldap: connection lost"))
+	invalid := errors.New("invalid credentials")
+
+	table := []struct {
+		name          string
+		errs          []error
+		maxAttempts   int
+		expectedCalls int
+		expectedErr   error
+	}{
+		{
+			name:          "it succeed after retrying transient errors",
+			errs:          []error{transient, transient},
+			maxAttempts:   3,
+			expectedCalls: 3,
+		},
+		{
+			name:          "it return last error when attempts exhausted",
+			errs:          []error{transient, transient},
+			maxAttempts:   2,
+			expectedCalls: 2,
+			expectedErr:   transient,
+		},
+		{
+			name:          "it does not retry non transient errors",
+			errs:          []error{invalid},
+			maxAttempts:   3,
+			expectedCalls: 1,
+			expectedErr:   invalid,
+		},
+		{
+			name:          "it call the strategy at least once",
+			maxAttempts:   0,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockStrategy{errs: tt.errs}
+			s := WithRetry(m, tt.maxAttempts, time.Millisecond)
+			info, err := s.Authenticate(context.TODO(), nil)
+			assert.Equal(t, tt.expectedCalls, m.calls)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, "test", info.GetUserName())
+			}
+		})
+	}
+}
+
+func TestWithRetryContext(t *testing.T) {
+	transient := Retryable(errors.New("timeout"))
+	m := &mockStrategy{errs: []error{transient, transient}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := WithRetry(m, 3, time.Hour).Authenticate(ctx, nil)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Contains(t, err.Error(), "timeout")
+	assert.Equal(t, 1, m.calls)
+}
+
+func TestIsTransient(t *testing.T) {
+	table := []struct {
+		err      error
+		expected bool
+	}{
+		{err: errors.New("invalid credentials"), expected: false},
+		{err: Retryable(errors.New("x")), expected: true},
+		{err: fmt.Errorf("strategies/ldap: %w", Retryable(errors.New("x"))), expected: true},
+		{err: &net.OpError{Op: "dial", Err: timeoutErr{}}, expected: true},
+		{err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, expected: true},
+		{err: fmt.Errorf("wrapped: %w", syscall.ECONNREFUSED), expected: true},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransient(tt.err))
+		})
+	}
+
+	assert.Nil(t, Retryable(nil))
+}
+
+func TestBackoff(t *testing.T) {
+	s := strategy{base: time.Second * 2}
+	for attempt, max := range []time.Duration{2, 4, 8} {
+		d := s.backoff(attempt + 1)
+		assert.True(t, d >= max*time.Second/2 && d <= max*time.Second, d)
+	}
+}
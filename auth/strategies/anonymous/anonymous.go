@@ -0,0 +1,32 @@
+// Package anonymous provides authentication strategy,
+// that authenticate any HTTP request as the anonymous user.
+// Typically used as the last strategy of a union,
+// to ensure user info always available in the request context for public routes.
+package anonymous
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// UserName is the anonymous user name.
+const UserName = "anonymous"
+
+type anonymous struct{}
+
+func (anonymous) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	return auth.NewUserInfo(UserName, "", nil, nil), nil
+}
+
+// IsAnonymous reports whether info is the anonymous user.
+func IsAnonymous(info auth.Info) bool {
+	return info != nil && info.GetUserName() == UserName && len(info.GetID()) == 0
+}
+
+// New return strategy that never fails,
+// and return a new anonymous user info for each request.
+func New() auth.Strategy {
+	return anonymous{}
+}
@@ -0,0 +1,106 @@
+package anonymous
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/union"
+	"github.com/shaj13/go-guardian/v2/middleware"
+)
+
+func TestAnonymous(t *testing.T) {
+	table := []struct {
+		name    string
+		prepare func(r *http.Request)
+	}{
+		{
+			name:    "it authenticate request without authorization header",
+			prepare: func(r *http.Request) {},
+		},
+		{
+			name: "it authenticate request with authorization header",
+			prepare: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer token")
+			},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			tt.prepare(r)
+			info, err := New().Authenticate(r.Context(), r)
+			assert.NoError(t, err)
+			assert.Equal(t, UserName, info.GetUserName())
+			assert.True(t, IsAnonymous(info))
+		})
+	}
+}
+
+func TestAnonymousFallback(t *testing.T) {
+	fn := func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		if userName == "admin" && password == "admin" {
+			return auth.NewUserInfo("admin", "1", nil, nil), nil
+		}
+		return nil, errors.New("invalid credentials")
+	}
+
+	s := union.New(basic.New(fn), New())
+
+	var got auth.Info
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = auth.User(r)
+	})
+	h := middleware.Authenticate(s)(next)
+
+	table := []struct {
+		name      string
+		prepare   func(r *http.Request)
+		user      string
+		anonymous bool
+	}{
+		{
+			name:      "it populate context with anonymous user without credentials",
+			prepare:   func(r *http.Request) {},
+			user:      UserName,
+			anonymous: true,
+		},
+		{
+			name: "it populate context with anonymous user on invalid credentials",
+			prepare: func(r *http.Request) {
+				r.SetBasicAuth("admin", "wrong")
+			},
+			user:      UserName,
+			anonymous: true,
+		},
+		{
+			name: "it populate context with authenticated user",
+			prepare: func(r *http.Request) {
+				r.SetBasicAuth("admin", "admin")
+			},
+			user: "admin",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			tt.prepare(r)
+			h.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.user, got.GetUserName())
+			assert.Equal(t, tt.anonymous, IsAnonymous(got))
+		})
+	}
+
+	assert.False(t, IsAnonymous(nil))
+}
@@ -0,0 +1,33 @@
+package anonymous_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/anonymous"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/union"
+)
+
+func Example() {
+	validate := func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		return auth.NewUserInfo(userName, "1", nil, nil), nil
+	}
+
+	// anonymous strategy must be the last strategy in the chain.
+	strategy := union.New(basic.New(validate), anonymous.New())
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	info, _ := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), anonymous.IsAnonymous(info))
+
+	r.SetBasicAuth("admin", "admin")
+	info, _ = strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), anonymous.IsAnonymous(info))
+
+	// Output:
+	// anonymous true
+	// admin false
+}
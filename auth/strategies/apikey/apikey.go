@@ -0,0 +1,84 @@
+// Package apikey provides authentication strategy,
+// to authenticate HTTP requests based on an opaque api key.
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal/flight"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+const (
+	// DefaultHeader is the default header where api key extracted from.
+	DefaultHeader = "X-API-Key"
+	// DefaultQueryParam is the default query param where api key extracted from,
+	// when the key missing from DefaultHeader.
+	DefaultQueryParam = "api_key"
+)
+
+// ValidateFunc declare function signature to validate api key.
+// ValidateFunc must return the key owner info, otherwise error.
+type ValidateFunc func(ctx context.Context, key string) (auth.Info, error)
+
+// GetAuthenticateFunc return function to authenticate request using api key validate function.
+// Concurrent calls for the same key are coalesced into one validate function call.
+// The returned function typically used with the token strategy.
+func GetAuthenticateFunc(fn ValidateFunc, opts ...auth.Option) token.AuthenticateFunc {
+	v := new(validator)
+	v.fn = fn
+	v.ttl = time.Minute * 5
+	for _, opt := range opts {
+		opt.Apply(v)
+	}
+	return v.authenticate
+}
+
+// New return strategy authenticate request using api key.
+// By default, the key extracted from DefaultHeader or DefaultQueryParam,
+// use token.SetParser to override it.
+//
+// New is similar to:
+//
+// 		fn := apikey.GetAuthenticateFunc(validate, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func New(fn ValidateFunc, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	parser := token.ChainParser(
+		token.XHeaderParser(DefaultHeader),
+		token.QueryParser(DefaultQueryParam),
+	)
+	opts = append([]auth.Option{token.SetParser(parser)}, opts...)
+	afn := GetAuthenticateFunc(fn, opts...)
+	return token.New(afn, c, opts...)
+}
+
+type validator struct {
+	fn     ValidateFunc
+	ttl    time.Duration
+	flight flight.Group
+}
+
+func (v *validator) authenticate(ctx context.Context, _ *http.Request, key string) (auth.Info, time.Time, error) {
+	i, err := v.flight.Do(key, func() (interface{}, error) {
+		// detach the shared call from the first caller cancellation.
+		ctx, cancel := flight.Detach(ctx)
+		defer cancel()
+		return v.fn(ctx, key)
+	})
+
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("strategies/apikey: %w", err)
+	}
+
+	info, ok := i.(auth.Info)
+	if !ok || info == nil {
+		return nil, time.Time{}, auth.NewTypeError("strategies/apikey:", (*auth.Info)(nil), i)
+	}
+
+	return info, time.Now().Add(v.ttl), nil
+}
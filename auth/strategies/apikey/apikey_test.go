@@ -0,0 +1,171 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestAPIKey(t *testing.T) {
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		if key == "valid" {
+			return auth.NewDefaultUser("test", "1", nil, nil), nil
+		}
+		return nil, fmt.Errorf("invalid key")
+	}
+
+	table := []struct {
+		name        string
+		prepare     func(r *http.Request)
+		expectedErr bool
+	}{
+		{
+			name:        "it return error when key missing",
+			prepare:     func(r *http.Request) {},
+			expectedErr: true,
+		},
+		{
+			name: "it return error when key invalid",
+			prepare: func(r *http.Request) {
+				r.Header.Set(DefaultHeader, "invalid")
+			},
+			expectedErr: true,
+		},
+		{
+			name: "it authenticate key from header",
+			prepare: func(r *http.Request) {
+				r.Header.Set(DefaultHeader, "valid")
+			},
+		},
+		{
+			name: "it authenticate key from query param",
+			prepare: func(r *http.Request) {
+				r.URL.RawQuery = DefaultQueryParam + "=valid"
+			},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(validate, libcache.LRU.New(0))
+			r, _ := http.NewRequest("GET", "/", nil)
+			tt.prepare(r)
+			info, err := s.Authenticate(r.Context(), r)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "test", info.GetUserName())
+		})
+	}
+}
+
+func TestAPIKeyTTL(t *testing.T) {
+	calls := int32(0)
+	revoked := int32(0)
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&revoked) == 1 {
+			return nil, fmt.Errorf("revoked key")
+		}
+		return auth.NewDefaultUser("test", "1", nil, nil), nil
+	}
+
+	s := New(validate, libcache.LRU.New(0), SetTTL(time.Millisecond*50))
+	authenticate := func() error {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set(DefaultHeader, "key")
+		_, err := s.Authenticate(r.Context(), r)
+		return err
+	}
+
+	assert.NoError(t, authenticate())
+	atomic.StoreInt32(&revoked, 1)
+	assert.NoError(t, authenticate(), "cached key must skip validate func")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Error(t, authenticate(), "revoked key must be rechecked after ttl expires")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAPIKeyCoalesce(t *testing.T) {
+	calls := int32(0)
+	release := make(chan struct{})
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return auth.NewDefaultUser("test", "1", nil, nil), nil
+	}
+
+	fn := GetAuthenticateFunc(validate)
+	wg := sync.WaitGroup{}
+	started := sync.WaitGroup{}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		started.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			info, _, err := fn(context.TODO(), nil, "key")
+			assert.NoError(t, err)
+			assert.Equal(t, "test", info.GetUserName())
+		}()
+	}
+
+	started.Wait()
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestAPIKeyCoalesceFirstCallerCanceled(t *testing.T) {
+	release := make(chan struct{})
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return auth.NewDefaultUser("test", "1", nil, nil), nil
+	}
+
+	fn := GetAuthenticateFunc(validate)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan error)
+	go func() {
+		_, _, err := fn(ctx, nil, "key")
+		first <- err
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	second := make(chan error)
+	go func() {
+		_, _, err := fn(context.Background(), nil, "key")
+		second <- err
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	cancel()
+	close(release)
+
+	assert.NoError(t, <-first)
+	assert.NoError(t, <-second)
+}
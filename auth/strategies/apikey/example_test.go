@@ -0,0 +1,48 @@
+package apikey_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/apikey"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func Example() {
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		if key == "secret" {
+			return auth.NewDefaultUser("example", "1", nil, nil), nil
+		}
+		return nil, fmt.Errorf("Invalid api key")
+	}
+
+	strategy := apikey.New(validate, libcache.LRU.New(0))
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("X-API-Key", "secret")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+	// Output:
+	// example <nil>
+}
+
+func ExampleNew_header() {
+	validate := func(ctx context.Context, key string) (auth.Info, error) {
+		return auth.NewDefaultUser("example", "1", nil, nil), nil
+	}
+
+	opt := token.SetParser(token.XHeaderParser("X-Service-Key"))
+	strategy := apikey.New(validate, libcache.LRU.New(0), opt)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Service-Key", "secret")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+	// Output:
+	// example <nil>
+}
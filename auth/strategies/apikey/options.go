@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetTTL sets how long a validated api key cached,
+// before the validate function invoked again.
+// Default Value 5 Minutes.
+func SetTTL(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*validator); ok {
+			v.ttl = d
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTTL(t *testing.T) {
+	v := new(validator)
+	opt := SetTTL(time.Hour)
+	opt.Apply(v)
+	assert.Equal(t, time.Hour, v.ttl)
+}
@@ -0,0 +1,171 @@
+// Package azuread provide auth strategy to authenticate,
+// incoming HTTP requests using Azure Active Directory (Microsoft Entra ID) access tokens.
+// The token signing keys discovered from the tenant OpenID configuration,
+// using the oidc strategy discovery.
+package azuread
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oidc"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// DefaultAuthority is the Azure AD global cloud authority.
+const DefaultAuthority = "https://login.microsoftonline.com"
+
+const (
+	overageExtName    = "x-go-guardian-azuread-groups-overage"
+	tenantPlaceholder = "{tenantid}"
+)
+
+// Claims represents Azure AD access token claims.
+// Claims implements oauth2.ClaimsResolver.
+type Claims struct {
+	*claims.Standard
+	UPN               string              `json:"upn,omitempty"`
+	PreferredUsername string              `json:"preferred_username,omitempty"`
+	ObjectID          string              `json:"oid,omitempty"`
+	TenantID          string              `json:"tid,omitempty"`
+	Scp               claims.StringOrList `json:"scp,omitempty"`
+	Groups            []string            `json:"groups,omitempty"`
+	Roles             []string            `json:"roles,omitempty"`
+	ClaimNames        map[string]string   `json:"_claim_names,omitempty"`
+	ClaimSources      map[string]struct {
+		Endpoint string `json:"endpoint"`
+	} `json:"_claim_sources,omitempty"`
+	// issuer is the discovered issuer,
+	// holding the {tenantid} placeholder for multi-tenant applications.
+	issuer string
+}
+
+// New return's a new Claims as oauth2.ClaimsResolver.
+func (c Claims) New() oauth2.ClaimsResolver {
+	return &Claims{Standard: new(claims.Standard), issuer: c.issuer}
+}
+
+// Verify verifies the token claims,
+// where the expected issuer is the discovered issuer,
+// with the {tenantid} placeholder replaced by the token tid claim.
+func (c Claims) Verify(opts claims.VerifyOptions) error {
+	if strings.Contains(c.issuer, tenantPlaceholder) {
+		opts.Issuer = strings.Replace(c.issuer, tenantPlaceholder, c.TenantID, 1)
+	}
+	return c.Standard.Verify(opts)
+}
+
+// GetExpiresAt return's c.ExpiresAt.
+func (c Claims) GetExpiresAt() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*c.ExpiresAt)
+}
+
+// GetScope return's c.Scp.
+func (c Claims) GetScope() []string {
+	return c.Scp.Split()
+}
+
+// Resolve return's user info from c, where the user name is the upn or preferred_username,
+// the id is the object id, and groups are the groups object ids.
+func (c Claims) Resolve() auth.Info {
+	name := c.UPN
+	if len(name) == 0 {
+		name = c.PreferredUsername
+	}
+
+	id := c.ObjectID
+	if len(id) == 0 {
+		id = c.Subject
+	}
+
+	ext := auth.Extensions{}
+	ext.Set("tid", c.TenantID)
+	if len(c.Roles) > 0 {
+		ext["roles"] = c.Roles
+	}
+
+	// groups overage, the user is a member of more groups than fit in the token,
+	// azure replace groups claim with a reference to graph api endpoint.
+	if src, ok := c.ClaimSources[c.ClaimNames["groups"]]; ok && len(src.Endpoint) > 0 {
+		ext.Set(overageExtName, src.Endpoint)
+	}
+
+	return auth.NewUserInfo(name, id, c.Groups, ext)
+}
+
+// GroupsOverage return the Graph API endpoint to query the user groups,
+// and true when the token groups claim was replaced due to the groups overage,
+// Otherwise, it return empty string and false.
+//
+// Querying the endpoint requires a Graph API token,
+// e.g. obtained using the on-behalf-of flow, and therefore left to the caller.
+func GroupsOverage(info auth.Info) (string, bool) {
+	v := info.GetExtensions().Get(overageExtName)
+	return v, len(v) > 0
+}
+
+// multiTenant reports whether tenantID is one of the azure multi-tenant endpoints,
+// where the discovered issuer holds the {tenantid} placeholder.
+func multiTenant(tenantID string) bool {
+	switch strings.ToLower(tenantID) {
+	case "common", "organizations", "consumers":
+		return true
+	}
+	return false
+}
+
+// verifier return oidc.ConfigurationVerifier, that verify tokens issued to the client id,
+// by the tenant or by the token tenant when multi-tenant.
+func verifier(tenantID, clientID string) oidc.ConfigurationVerifier {
+	return func(cfg oidc.Configuration) ([]auth.Option, error) {
+		issuer := cfg.Issuer
+		if !multiTenant(tenantID) {
+			issuer = strings.Replace(issuer, tenantPlaceholder, tenantID, 1)
+		}
+
+		return []auth.Option{
+			jwt.SetClaimResolver(&Claims{issuer: issuer}),
+			jwt.SetVerifyOptions(claims.VerifyOptions{
+				Audience: []string{clientID},
+				Issuer:   issuer,
+			}),
+		}, nil
+	}
+}
+
+// GetAuthenticateFunc return function to authenticate request using Azure AD tokens,
+// issued by the tenant to the application client id.
+// The returned function typically used with the token strategy.
+//
+// When tenantID is "common", "organizations" or "consumers",
+// tokens issued by any tenant accepted, where the token issuer verified against its tid claim,
+// the application must check the "tid" info extension to restrict the allowed tenants.
+func GetAuthenticateFunc(tenantID, clientID string, opts ...auth.Option) token.AuthenticateFunc {
+	return newAzureAD(DefaultAuthority, tenantID, clientID, opts...)
+}
+
+// New return strategy authenticate request using Azure AD tokens,
+// issued by the tenant to the application client id.
+// The tenant openid configuration discovered on the first authentication,
+// and retried on subsequent authentications until succeeded.
+// See GetAuthenticateFunc for multi-tenant applications.
+//
+// The opts passed to the oidc and oauth2 jwt strategies,
+// use oidc.SetHTTPClient and jwt.SetVerifyOptions e.g. to override the defaults.
+func New(tenantID, clientID string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	fn := GetAuthenticateFunc(tenantID, clientID, opts...)
+	return token.New(fn, c, opts...)
+}
+
+func newAzureAD(authority, tenantID, clientID string, opts ...auth.Option) token.AuthenticateFunc {
+	issuer := strings.TrimSuffix(authority, "/") + "/" + tenantID
+	opts = append([]auth.Option{oidc.SetConfigurationVerifier(verifier(tenantID, clientID))}, opts...)
+	return oidc.GetAuthenticateFunc(issuer, clientID, opts...)
+}
@@ -0,0 +1,185 @@
+package azuread
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func TestAzureAD(t *testing.T) {
+	srv, discoveries := mockAzureAD(t, 0)
+	defer srv.Close()
+	fn := newAzureAD(srv.URL, "test-tenant", "client-id")
+
+	groups := []string{"group-1", "group-2"}
+
+	table := []struct {
+		name        string
+		file        string
+		expectedErr string
+		username    string
+		groups      []string
+		overage     string
+	}{
+		{
+			name:     "it return user info when rs256 token valid",
+			file:     "rs256_token",
+			username: "jdoe@example.com",
+			groups:   groups,
+		},
+		{
+			name:     "it return user info when es256 token valid",
+			file:     "es256_token",
+			username: "jdoe@example.com",
+			groups:   groups,
+		},
+		{
+			name:     "it return user info with preferred username when upn missing",
+			file:     "preferred_username_token",
+			username: "jdoe@example.org",
+			groups:   groups,
+		},
+		{
+			name:     "it return user info with graph endpoint when groups overage",
+			file:     "groups_overage_token",
+			username: "jdoe@example.com",
+			overage:  "https://graph.windows.net/test-tenant/users/00000000-0000-0000-0000-000000000001/getMemberObjects",
+		},
+		{
+			name:        "it return error when token intended for another client",
+			file:        "invalid_audience_token",
+			expectedErr: "audience",
+		},
+		{
+			name:        "it return error when token issued by another tenant",
+			file:        "invalid_issuer_token",
+			expectedErr: "issuer",
+		},
+		{
+			name:        "it return error when token expired",
+			file:        "expired_token",
+			expectedErr: "expired",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			info, _, err := fn(context.TODO(), nil, readFile(t, tt.file))
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.username, info.GetUserName())
+			assert.Equal(t, "00000000-0000-0000-0000-000000000001", info.GetID())
+			assert.Equal(t, tt.groups, info.GetGroups())
+			assert.Equal(t, "test-tenant", info.GetExtensions().Get("tid"))
+			assert.Equal(t, []string{"User.Read", "Files.Read"}, token.GetNamedScopes(info))
+
+			endpoint, ok := GroupsOverage(info)
+			assert.Equal(t, tt.overage, endpoint)
+			assert.Equal(t, len(tt.overage) > 0, ok)
+		})
+	}
+
+	assert.Equal(t, 1, *discoveries)
+}
+
+func TestAzureADDiscoveryRetry(t *testing.T) {
+	srv, discoveries := mockAzureAD(t, 1)
+	defer srv.Close()
+	fn := newAzureAD(srv.URL, "test-tenant", "client-id")
+	tk := readFile(t, "rs256_token")
+
+	_, _, err := fn(context.TODO(), nil, tk)
+	assert.Contains(t, err.Error(), "503 status code")
+
+	_, _, err = fn(context.TODO(), nil, tk)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *discoveries)
+}
+
+func TestAzureADMultiTenant(t *testing.T) {
+	srv, _ := mockAzureAD(t, 0)
+	defer srv.Close()
+	fn := newAzureAD(srv.URL, "common", "client-id")
+
+	table := []struct {
+		name        string
+		file        string
+		expectedErr string
+	}{
+		{
+			name: "it verify the token issuer against the token tenant",
+			file: "rs256_token",
+		},
+		{
+			name:        "it return error when token issuer does not match the token tenant",
+			file:        "invalid_issuer_token",
+			expectedErr: "issuer",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			info, _, err := fn(context.TODO(), nil, readFile(t, tt.file))
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "test-tenant", info.GetExtensions().Get("tid"))
+		})
+	}
+}
+
+func TestGroupsOverage(t *testing.T) {
+	_, ok := GroupsOverage(auth.NewUserInfo("jdoe", "1", nil, nil))
+	assert.False(t, ok)
+}
+
+func readFile(tb testing.TB, file string) string {
+	b, err := ioutil.ReadFile("./testdata/" + file)
+	if err != nil {
+		tb.Fatalf("Failed to read testdata file Err: %s", err)
+	}
+	return string(b)
+}
+
+// mockAzureAD mock azure ad openid configuration and jwks endpoints,
+// where the first failures discovery requests fails.
+func mockAzureAD(tb testing.TB, failures int) (*httptest.Server, *int) {
+	keys := readFile(tb, "jwks.json")
+	discoveries := 0
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	discovery := func(w http.ResponseWriter, r *http.Request) {
+		discoveries++
+		if discoveries <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"issuer":"https://sts.windows.net/{tenantid}/","jwks_uri":"` + srv.URL + `/keys"}`))
+	}
+
+	mux.HandleFunc("/test-tenant/.well-known/openid-configuration", discovery)
+	mux.HandleFunc("/common/.well-known/openid-configuration", discovery)
+
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(keys))
+	})
+
+	return srv, &discoveries
+}
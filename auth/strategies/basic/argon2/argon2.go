@@ -0,0 +1,144 @@
+// Package argon2 provides basic authentication strategy,
+// that verify user passwords against Argon2id hashes,
+// encoded in the PHC string format "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>".
+package argon2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+// ErrInvalidHash is returned by Compare method,
+// when the hashed password is not a valid Argon2id PHC string.
+var ErrInvalidHash = errors.New("strategies/basic/argon2: Invalid hash format")
+
+// DefaultParams are the RFC 9106 second recommended option,
+// for memory constrained environments.
+var DefaultParams = Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLen:     16,
+	KeyLen:      32,
+}
+
+// Params represents Argon2id cost parameters.
+type Params struct {
+	// Memory in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLen     uint32
+	KeyLen      uint32
+}
+
+// Hasher implements basic.Comparator using Argon2id.
+// Hash generate new hash using the params,
+// while Compare use the params encoded in the hashed password.
+type Hasher struct {
+	Params Params
+}
+
+// Hash generate Argon2id PHC string of the password with random salt.
+func (h Hasher) Hash(password string) (string, error) {
+	p := h.Params
+	salt := make([]byte, p.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("strategies/basic/argon2: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLen)
+	return encode(p, salt, key), nil
+}
+
+// Compare compares Argon2id hashed password with its possible plaintext equivalent.
+func (h Hasher) Compare(hashedPassword, password string) error {
+	p, salt, key, err := decode(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLen)
+	if subtle.ConstantTimeCompare(key, other) == 1 {
+		return nil
+	}
+
+	return basic.ErrInvalidCredentials
+}
+
+// Verify reports whether password match the Argon2id hash,
+// using constant time comparison.
+func Verify(password, hash string) bool {
+	return Hasher{}.Compare(hash, password) == nil
+}
+
+func encode(p Params, salt, key []byte) string {
+	b64 := base64.RawStdEncoding.EncodeToString
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism, b64(salt), b64(key),
+	)
+}
+
+func decode(hash string) (p Params, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism)
+	if err != nil || p.Iterations == 0 || p.Parallelism == 0 {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(key) == 0 {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	p.SaltLen = uint32(len(salt))
+	p.KeyLen = uint32(len(key))
+
+	return p, salt, key, nil
+}
+
+// New return basic strategy, that look up the user Argon2id password hash,
+// and verify it against the request password.
+// Params used to hash new passwords via Hasher,
+// and could be passed to basic.SetComparator when caching the auth decision.
+func New(params Params, lookup basic.UserLookup, opts ...auth.Option) auth.Strategy {
+	h := Hasher{Params: params}
+	fn := func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		hash, info, err := lookup(ctx, userName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.Compare(hash, password); err != nil {
+			return nil, err
+		}
+
+		return info, nil
+	}
+
+	return basic.New(fn, opts...)
+}
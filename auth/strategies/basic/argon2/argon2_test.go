@@ -0,0 +1,128 @@
+package argon2
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+// The RFC 9106 section 5.3 vector requires secret and associated data,
+// which golang.org/x/crypto/argon2 does not expose,
+// hence the reference implementation (phc-winner-argon2) vectors used instead.
+func TestVectors(t *testing.T) {
+	table := []struct {
+		hash string
+		raw  string
+	}{
+		{
+			hash: "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			raw:  "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7",
+		},
+		{
+			hash: "$argon2id$v=19$m=256,t=2,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4",
+			raw:  "9dfeb910e80bad0311fee20f9c0e2b12c17987b4cac90c2ef54d5b3021c68bfe",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.hash, func(t *testing.T) {
+			p, salt, key, err := decode(tt.hash)
+			assert.NoError(t, err)
+			assert.Equal(t, "somesalt", string(salt))
+			assert.Equal(t, tt.raw, hex.EncodeToString(key))
+			assert.Equal(t, tt.hash, encode(p, salt, key))
+			assert.True(t, Verify("password", tt.hash))
+			assert.False(t, Verify("Password", tt.hash))
+			assert.False(t, Verify("", tt.hash))
+		})
+	}
+}
+
+func TestHasher(t *testing.T) {
+	h := Hasher{Params: Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLen: 8, KeyLen: 16}}
+
+	hash, err := h.Hash("secret")
+	assert.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=64,t=1,p=1$")
+
+	other, _ := h.Hash("secret")
+	assert.NotEqual(t, hash, other, "salt must be random")
+
+	assert.NoError(t, h.Compare(hash, "secret"))
+	assert.Equal(t, basic.ErrInvalidCredentials, h.Compare(hash, "wrong"))
+}
+
+func TestDecodeInvalidHash(t *testing.T) {
+	table := []string{
+		"",
+		"plain",
+		"$argon2i$v=19$m=256,t=2,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4",
+		"$argon2id$v=16$m=256,t=2,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4",
+		"$argon2id$v=19$m=256,t=0,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4",
+		"$argon2id$v=19$m=256,t=2,p=1$!!$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4",
+		"$argon2id$v=19$m=256,t=2,p=1$c29tZXNhbHQ$",
+	}
+
+	for _, hash := range table {
+		err := Hasher{}.Compare(hash, "password")
+		assert.Equal(t, ErrInvalidHash, err, hash)
+	}
+}
+
+func TestNew(t *testing.T) {
+	const hash = "$argon2id$v=19$m=256,t=2,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4"
+	errNotFound := errors.New("user not found")
+
+	lookup := func(ctx context.Context, userName string) (string, auth.Info, error) {
+		if userName != "test" {
+			return "", nil, errNotFound
+		}
+		return hash, auth.NewDefaultUser(userName, "1", nil, nil), nil
+	}
+
+	table := []struct {
+		name        string
+		user        string
+		password    string
+		expectedErr error
+	}{
+		{
+			name:     "it return user info when password valid",
+			user:     "test",
+			password: "password",
+		},
+		{
+			name:        "it return error when password invalid",
+			user:        "test",
+			password:    "wrong",
+			expectedErr: basic.ErrInvalidCredentials,
+		},
+		{
+			name:        "it return lookup error",
+			user:        "unknown",
+			password:    "password",
+			expectedErr: errNotFound,
+		},
+	}
+
+	s := New(DefaultParams, lookup)
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.SetBasicAuth(tt.user, tt.password)
+			info, err := s.Authenticate(r.Context(), r)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.user, info.GetUserName())
+			}
+		})
+	}
+}
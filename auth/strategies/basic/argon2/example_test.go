@@ -0,0 +1,40 @@
+package argon2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic/argon2"
+)
+
+func Example() {
+	h := argon2.Hasher{Params: argon2.DefaultParams}
+	// typically hashed once, at user registration and stored in DB.
+	hash, _ := h.Hash("admin")
+
+	lookup := func(ctx context.Context, userName string) (string, auth.Info, error) {
+		return hash, auth.NewDefaultUser(userName, "1", nil, nil), nil
+	}
+
+	strategy := argon2.New(argon2.DefaultParams, lookup)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("admin", "admin")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+
+	// Output:
+	// admin <nil>
+}
+
+func ExampleVerify() {
+	hash := "$argon2id$v=19$m=256,t=2,p=1$c29tZXNhbHQ$nf65EOgLrQMR/uIPnA4rEsF5h7TKyQwu9U1bMCHGi/4"
+	fmt.Println(argon2.Verify("password", hash))
+	fmt.Println(argon2.Verify("wrong", hash))
+
+	// Output:
+	// true
+	// false
+}
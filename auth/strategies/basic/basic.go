@@ -26,6 +26,10 @@ var (
 // with ErrMissingPrams returned, Otherwise, return Authenticate invocation result.
 type AuthenticateFunc func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error)
 
+// UserLookup declare function signature to look up user password hash and info by user name,
+// used by the password hashing strategies e.g argon2 and scrypt.
+type UserLookup func(ctx context.Context, userName string) (hash string, info auth.Info, err error)
+
 type basic struct {
 	fn     AuthenticateFunc
 	parser Parser
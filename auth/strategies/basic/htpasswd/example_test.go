@@ -0,0 +1,23 @@
+package htpasswd_test
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic/htpasswd"
+)
+
+func Example() {
+	strategy, err := htpasswd.New("testdata/bcrypt.htpasswd", htpasswd.SetReloadInterval(time.Minute))
+	if err != nil {
+		panic(err)
+	}
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("alice", "password")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+	// Output:
+	// alice <nil>
+}
@@ -0,0 +1,121 @@
+package htpasswd
+
+import (
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+const (
+	prefixSHA  = "{SHA}"
+	prefixAPR1 = "$apr1$"
+	itoa64     = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// compare compares the htpasswd hashed password with its possible plaintext equivalent.
+func compare(hashedPassword, password string) error {
+	var hash string
+
+	switch {
+	case strings.HasPrefix(hashedPassword, "$2"):
+		if bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) != nil {
+			return basic.ErrInvalidCredentials
+		}
+		return nil
+	case strings.HasPrefix(hashedPassword, prefixSHA):
+		sum := sha1.Sum([]byte(password)) //nolint:gosec
+		hash = prefixSHA + base64.StdEncoding.EncodeToString(sum[:])
+	case strings.HasPrefix(hashedPassword, prefixAPR1):
+		salt := strings.SplitN(strings.TrimPrefix(hashedPassword, prefixAPR1), "$", 2)[0]
+		hash = apr1(password, salt)
+	default:
+		return ErrUnsupportedHash
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(hashedPassword)) == 1 {
+		return nil
+	}
+
+	return basic.ErrInvalidCredentials
+}
+
+// apr1 return the Apache MD5 crypt of the password and salt,
+// as implemented by APR apr_md5_encode.
+func apr1(password, salt string) string {
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+
+	pw := []byte(password)
+
+	alt := md5.New() //nolint:gosec
+	alt.Write(pw)
+	alt.Write([]byte(salt))
+	alt.Write(pw)
+	altSum := alt.Sum(nil)
+
+	ctx := md5.New() //nolint:gosec
+	ctx.Write(pw)
+	ctx.Write([]byte(prefixAPR1))
+	ctx.Write([]byte(salt))
+
+	for i := len(pw); i > 0; i -= 16 {
+		n := i
+		if n > 16 {
+			n = 16
+		}
+		ctx.Write(altSum[:n])
+	}
+
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pw[:1])
+		}
+	}
+
+	sum := ctx.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		round := md5.New() //nolint:gosec
+		if i&1 == 1 {
+			round.Write(pw)
+		} else {
+			round.Write(sum)
+		}
+		if i%3 != 0 {
+			round.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			round.Write(pw)
+		}
+		if i&1 == 1 {
+			round.Write(sum)
+		} else {
+			round.Write(pw)
+		}
+		sum = round.Sum(nil)
+	}
+
+	buf := new(strings.Builder)
+	to64 := func(v uint32, n int) {
+		for ; n > 0; n-- {
+			buf.WriteByte(itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+
+	for _, g := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		to64(uint32(sum[g[0]])<<16|uint32(sum[g[1]])<<8|uint32(sum[g[2]]), 4)
+	}
+	to64(uint32(sum[11]), 2)
+
+	return prefixAPR1 + salt + "$" + buf.String()
+}
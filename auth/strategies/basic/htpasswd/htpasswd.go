@@ -0,0 +1,186 @@
+// Package htpasswd provides basic authentication strategy,
+// that verify user passwords against an Apache htpasswd file,
+// supporting bcrypt, SHA1 "{SHA}" and Apache MD5 "$apr1$" hashes.
+package htpasswd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+// ErrUnsupportedHash is returned by Authenticate Strategy method,
+// when the user password hash scheme is not supported e.g crypt or plain text.
+var ErrUnsupportedHash = errors.New("strategies/basic/htpasswd: Unsupported password hash scheme")
+
+// File represents an htpasswd file users,
+// optionally reloaded when the file changes.
+type File struct {
+	mu        sync.RWMutex
+	path      string
+	users     map[string]string
+	interval  time.Duration
+	checkedAt time.Time
+	modTime   time.Time
+	size      int64
+	now       func() time.Time
+}
+
+// Authenticate compares the user password against the user hash in the htpasswd file,
+// and return user info with the user name as name and id.
+func (f *File) Authenticate(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+	f.reload()
+
+	f.mu.RLock()
+	hash, ok := f.users[userName]
+	f.mu.RUnlock()
+
+	if !ok {
+		return nil, basic.ErrInvalidCredentials
+	}
+
+	if err := compare(hash, password); err != nil {
+		return nil, err
+	}
+
+	return auth.NewUserInfo(userName, userName, nil, nil), nil
+}
+
+// reload reloads the file when the reload interval elapsed since the last check,
+// and the file modification time or size changed.
+// The current users kept when reloading fails.
+func (f *File) reload() {
+	if f.interval <= 0 {
+		return
+	}
+
+	now := f.now()
+
+	f.mu.RLock()
+	due := now.Sub(f.checkedAt) >= f.interval
+	f.mu.RUnlock()
+
+	if !due {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// another caller may have checked the file meanwhile.
+	if now.Sub(f.checkedAt) < f.interval {
+		return
+	}
+
+	f.checkedAt = now
+
+	fi, err := os.Stat(f.path)
+	if err != nil || (fi.ModTime().Equal(f.modTime) && fi.Size() == f.size) {
+		return
+	}
+
+	_ = f.load()
+}
+
+func (f *File) load() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return fmt.Errorf("strategies/basic/htpasswd: %w", err)
+	}
+
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("strategies/basic/htpasswd: %w", err)
+	}
+
+	users, err := Parse(file)
+	if err != nil {
+		return err
+	}
+
+	f.users = users
+	f.modTime = fi.ModTime()
+	f.size = fi.Size()
+
+	return nil
+}
+
+// Parse parses htpasswd entries "user:hash" from r, one per line.
+// Empty lines and lines starting with # ignored.
+func Parse(r io.Reader) (map[string]string, error) {
+	users := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		i := strings.Index(line, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("strategies/basic/htpasswd: Malformed entry at line %d", n)
+		}
+
+		users[line[:i]] = line[i+1:]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("strategies/basic/htpasswd: %w", err)
+	}
+
+	return users, nil
+}
+
+// NewFile return File loaded from the htpasswd file at path.
+func NewFile(path string, opts ...auth.Option) (*File, error) {
+	f := new(File)
+	f.path = path
+	f.now = time.Now
+
+	for _, opt := range opts {
+		opt.Apply(f)
+	}
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+
+	f.checkedAt = f.now()
+
+	return f, nil
+}
+
+// New return strategy authenticate request using the htpasswd file at path.
+// The opts passed to the basic strategy e.g basic.SetParser.
+func New(path string, opts ...auth.Option) (auth.Strategy, error) {
+	f, err := NewFile(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return basic.New(f.Authenticate, opts...), nil
+}
+
+// SetReloadInterval sets the interval to check the htpasswd file for changes,
+// the file is checked on authentication at most once per interval,
+// and reloaded when its modification time or size changed.
+// Default: 0 (disabled)
+func SetReloadInterval(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if f, ok := v.(*File); ok {
+			f.interval = d
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package htpasswd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+func TestFileAuthenticate(t *testing.T) {
+	table := []struct {
+		name        string
+		file        string
+		user        string
+		password    string
+		expectedErr error
+	}{
+		{name: "it authenticate bcrypt 2y user", file: "bcrypt", user: "alice", password: "password"},
+		{name: "it authenticate bcrypt 2a user", file: "bcrypt", user: "bob", password: "secret"},
+		{name: "it authenticate sha1 user", file: "sha1", user: "alice", password: "password"},
+		{name: "it authenticate apr1 user", file: "apr1", user: "alice", password: "password"},
+		{name: "it authenticate apr1 user with another salt", file: "apr1", user: "bob", password: "secret"},
+		{
+			name:        "it return error when bcrypt password invalid",
+			file:        "bcrypt",
+			user:        "alice",
+			password:    "secret",
+			expectedErr: basic.ErrInvalidCredentials,
+		},
+		{
+			name:        "it return error when sha1 password invalid",
+			file:        "sha1",
+			user:        "alice",
+			password:    "secret",
+			expectedErr: basic.ErrInvalidCredentials,
+		},
+		{
+			name:        "it return error when apr1 password invalid",
+			file:        "apr1",
+			user:        "alice",
+			password:    "secret",
+			expectedErr: basic.ErrInvalidCredentials,
+		},
+		{
+			name:        "it return error when user unknown",
+			file:        "apr1",
+			user:        "eve",
+			password:    "password",
+			expectedErr: basic.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFile("testdata/" + tt.file + ".htpasswd")
+			assert.NoError(t, err)
+
+			info, err := f.Authenticate(context.TODO(), nil, tt.user, tt.password)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, tt.user, info.GetUserName())
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	users, err := Parse(strings.NewReader("# comment\n\nalice:{SHA}x\n bob:$apr1$a$b \n"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": "{SHA}x", "bob": "$apr1$a$b"}, users)
+
+	_, err = Parse(strings.NewReader("alice:{SHA}x\nmalformed\n"))
+	assert.EqualError(t, err, "strategies/basic/htpasswd: Malformed entry at line 2")
+}
+
+func TestUnsupportedHash(t *testing.T) {
+	assert.Equal(t, ErrUnsupportedHash, compare("password", "password"))
+	assert.Equal(t, ErrUnsupportedHash, compare("rqXexS6ZhobKA", "password"))
+}
+
+func TestApr1(t *testing.T) {
+	// vectors generated using openssl passwd -apr1.
+	assert.Equal(t, "$apr1$r31DNYqN$JR8FICISqEALG/9ek2QPp/", apr1("password", "r31DNYqN"))
+	assert.Equal(t, "$apr1$8cQeGnnq$NAuyC3d/4EPjYvX/HcBEu/", apr1("secret", "8cQeGnnq"))
+}
+
+func TestNewFileMissing(t *testing.T) {
+	_, err := New("testdata/missing.htpasswd")
+	assert.Error(t, err)
+}
+
+func TestReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "htpasswd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".htpasswd")
+	write := func(content string) {
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("alice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n")
+
+	now := time.Now()
+	f, err := NewFile(path, SetReloadInterval(time.Minute))
+	assert.NoError(t, err)
+	f.now = func() time.Time { return now }
+
+	_, err = f.Authenticate(context.TODO(), nil, "alice", "password")
+	assert.NoError(t, err)
+
+	// change alice password to secret.
+	write("# rotated\nalice:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=\n")
+
+	// Round #1 -- within the reload interval the old password still accepted.
+	now = now.Add(time.Second * 30)
+	_, err = f.Authenticate(context.TODO(), nil, "alice", "password")
+	assert.NoError(t, err)
+
+	// Round #2 -- after the reload interval the new password accepted.
+	now = now.Add(time.Minute)
+	_, err = f.Authenticate(context.TODO(), nil, "alice", "secret")
+	assert.NoError(t, err)
+	_, err = f.Authenticate(context.TODO(), nil, "alice", "password")
+	assert.Equal(t, basic.ErrInvalidCredentials, err)
+
+	// Round #3 -- malformed file keeps the current users.
+	write("malformed\n")
+	now = now.Add(time.Minute * 2)
+	_, err = f.Authenticate(context.TODO(), nil, "alice", "secret")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package scrypt_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic/scrypt"
+)
+
+func Example() {
+	h := scrypt.Hasher{N: 32768, R: 8, P: 1, KeyLen: 32}
+	// typically hashed once, at user registration and stored in DB.
+	hash, _ := h.Hash("admin")
+
+	lookup := func(ctx context.Context, userName string) (string, auth.Info, error) {
+		return hash, auth.NewDefaultUser(userName, "1", nil, nil), nil
+	}
+
+	strategy := scrypt.New(32768, 8, 1, 32, lookup)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("admin", "admin")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+
+	// Output:
+	// admin <nil>
+}
@@ -0,0 +1,14 @@
+package scrypt
+
+import "github.com/shaj13/go-guardian/v2/auth"
+
+// SetRehashFunc sets the function invoked after successful authentication,
+// when the user password hash parameters differ from the strategy parameters,
+// to store the password hash upgraded to the strategy parameters.
+func SetRehashFunc(fn RehashFunc) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.rehash = fn
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package scrypt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRehashFunc(t *testing.T) {
+	s := new(strategy)
+	opt := SetRehashFunc(func(ctx context.Context, userName, hash string) error { return nil })
+	opt.Apply(s)
+	assert.NotNil(t, s.rehash)
+}
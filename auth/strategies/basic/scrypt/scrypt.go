@@ -0,0 +1,149 @@
+// Package scrypt provides basic authentication strategy,
+// that verify user passwords against scrypt hashes,
+// encoded in the self-describing format "$scrypt$N=32768$r=8$p=1$<salt>$<hash>",
+// hence parameters can vary per user, e.g during migration.
+package scrypt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+const saltLen = 16
+
+// ErrInvalidHash is returned by Compare method,
+// when the hashed password is not a valid scrypt self-describing string.
+var ErrInvalidHash = errors.New("strategies/basic/scrypt: Invalid hash format")
+
+// RehashFunc declare function signature to store the user upgraded password hash.
+// RehashFunc errors ignored, and the authentication succeeds with the old hash.
+type RehashFunc func(ctx context.Context, userName, hash string) error
+
+// Hasher implements basic.Comparator using scrypt.
+// Hash generate new hash using the hasher parameters,
+// while Compare use the parameters encoded in the hashed password.
+type Hasher struct {
+	N      int
+	R      int
+	P      int
+	KeyLen int
+}
+
+// Hash generate scrypt self-describing string of the password with random salt.
+func (h Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("strategies/basic/scrypt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, h.N, h.R, h.P, h.KeyLen)
+	if err != nil {
+		return "", fmt.Errorf("strategies/basic/scrypt: %w", err)
+	}
+
+	b64 := base64.RawStdEncoding.EncodeToString
+	return fmt.Sprintf("$scrypt$N=%d$r=%d$p=%d$%s$%s", h.N, h.R, h.P, b64(salt), b64(key)), nil
+}
+
+// Compare compares scrypt hashed password with its possible plaintext equivalent.
+func (h Hasher) Compare(hashedPassword, password string) error {
+	p, salt, key, err := decode(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other, err := scrypt.Key([]byte(password), salt, p.N, p.R, p.P, p.KeyLen)
+	if err != nil {
+		return ErrInvalidHash
+	}
+
+	if subtle.ConstantTimeCompare(key, other) == 1 {
+		return nil
+	}
+
+	return basic.ErrInvalidCredentials
+}
+
+// NeedsRehash reports whether the hashed password parameters differ from the hasher parameters.
+func (h Hasher) NeedsRehash(hashedPassword string) bool {
+	p, _, _, err := decode(hashedPassword)
+	return err != nil || p != h
+}
+
+func decode(hash string) (h Hasher, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 7 || parts[1] != "scrypt" {
+		return h, nil, nil, ErrInvalidHash
+	}
+
+	params := strings.Join(parts[2:5], "$")
+	if _, err := fmt.Sscanf(params, "N=%d$r=%d$p=%d", &h.N, &h.R, &h.P); err != nil {
+		return h, nil, nil, ErrInvalidHash
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return h, nil, nil, ErrInvalidHash
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[6]); err != nil || len(key) == 0 {
+		return h, nil, nil, ErrInvalidHash
+	}
+
+	h.KeyLen = len(key)
+
+	return h, salt, key, nil
+}
+
+type strategy struct {
+	hasher Hasher
+	lookup basic.UserLookup
+	rehash RehashFunc
+}
+
+func (s *strategy) authenticate(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+	hash, info, err := s.lookup(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hasher.Compare(hash, password); err != nil {
+		return nil, err
+	}
+
+	// rehash is best-effort, a failure to upgrade the hash does not fail
+	// the authentication, and the upgrade retried on the next login.
+	if s.rehash != nil && s.hasher.NeedsRehash(hash) {
+		if hash, err := s.hasher.Hash(password); err == nil {
+			_ = s.rehash(ctx, userName, hash)
+		}
+	}
+
+	return info, nil
+}
+
+// New return basic strategy, that look up the user scrypt password hash,
+// and verify it against the request password.
+// n, r, p and keyLen are the scrypt parameters used to hash new passwords,
+// Hashes with different parameters upgraded on successful login, see SetRehashFunc.
+func New(n, r, p, keyLen int, lookup basic.UserLookup, opts ...auth.Option) auth.Strategy {
+	s := new(strategy)
+	s.hasher = Hasher{N: n, R: r, P: p, KeyLen: keyLen}
+	s.lookup = lookup
+
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	return basic.New(s.authenticate, opts...)
+}
@@ -0,0 +1,101 @@
+package scrypt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+func TestHasher(t *testing.T) {
+	h := Hasher{N: 1024, R: 8, P: 1, KeyLen: 32}
+
+	hash, err := h.Hash("secret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$scrypt$N=1024$r=8$p=1$"))
+	assert.NoError(t, h.Compare(hash, "secret"))
+	assert.Equal(t, basic.ErrInvalidCredentials, h.Compare(hash, "wrong"))
+	assert.False(t, h.NeedsRehash(hash))
+
+	// Changing N without rehashing must fail the verification.
+	tampered := strings.Replace(hash, "N=1024", "N=2048", 1)
+	assert.Equal(t, basic.ErrInvalidCredentials, h.Compare(tampered, "secret"))
+
+	// Compare use the encoded parameters, regardless of the hasher parameters.
+	upgraded := Hasher{N: 2048, R: 8, P: 1, KeyLen: 32}
+	assert.NoError(t, upgraded.Compare(hash, "secret"))
+	assert.True(t, upgraded.NeedsRehash(hash))
+}
+
+func TestDecodeInvalidHash(t *testing.T) {
+	table := []string{
+		"",
+		"plain",
+		"$bcrypt$N=1024$r=8$p=1$c2FsdA$a2V5",
+		"$scrypt$N=x$r=8$p=1$c2FsdA$a2V5",
+		"$scrypt$N=1024$r=8$p=1$!!$a2V5",
+		"$scrypt$N=1024$r=8$p=1$c2FsdA$",
+		"$scrypt$N=1000$r=8$p=1$c2FsdA$a2V5",
+	}
+
+	for _, hash := range table {
+		err := Hasher{}.Compare(hash, "password")
+		assert.Equal(t, ErrInvalidHash, err, hash)
+	}
+}
+
+func TestNewUpgrade(t *testing.T) {
+	old := Hasher{N: 1024, R: 8, P: 1, KeyLen: 32}
+	stored, _ := old.Hash("password")
+	rehashed := 0
+	var rehashErr error
+
+	lookup := func(ctx context.Context, userName string) (string, auth.Info, error) {
+		return stored, auth.NewDefaultUser(userName, "1", nil, nil), nil
+	}
+
+	rehash := func(ctx context.Context, userName, hash string) error {
+		rehashed++
+		if rehashErr != nil {
+			return rehashErr
+		}
+		stored = hash
+		return nil
+	}
+
+	s := New(2048, 8, 1, 32, lookup, SetRehashFunc(rehash))
+
+	authenticate := func(password string) error {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.SetBasicAuth("test", password)
+		_, err := s.Authenticate(r.Context(), r)
+		return err
+	}
+
+	// Round #1 failed login does not upgrade the hash.
+	assert.Equal(t, basic.ErrInvalidCredentials, authenticate("wrong"))
+	assert.Equal(t, 0, rehashed)
+
+	// Round #2 failed upgrade does not fail the login.
+	rehashErr = errors.New("store unavailable")
+	assert.NoError(t, authenticate("password"))
+	assert.Equal(t, 1, rehashed)
+	assert.True(t, strings.HasPrefix(stored, "$scrypt$N=1024$r=8$p=1$"))
+	rehashErr = nil
+	rehashed = 0
+
+	// Round #3 successful login upgrade the hash to the new parameters.
+	assert.NoError(t, authenticate("password"))
+	assert.Equal(t, 1, rehashed)
+	assert.True(t, strings.HasPrefix(stored, "$scrypt$N=2048$r=8$p=1$"))
+
+	// Round #4 upgraded hash verified and not rehashed again.
+	assert.NoError(t, authenticate("password"))
+	assert.Equal(t, 1, rehashed)
+}
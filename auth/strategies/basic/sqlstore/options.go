@@ -0,0 +1,37 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetCache sets the cache to store looked up users.
+// Default: no caching.
+func SetCache(c auth.Cache) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if s, ok := v.(*Store); ok {
+			s.cache = c
+		}
+	})
+}
+
+// SetTTL sets the cached users ttl.
+// Default: 5 Minutes
+func SetTTL(ttl time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if s, ok := v.(*Store); ok {
+			s.ttl = ttl
+		}
+	})
+}
+
+// SetCacheBuster sets function reports whether the cached user is stale,
+// and must be looked up again, e.g after a password change webhook.
+func SetCacheBuster(fn func(userName string) bool) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if s, ok := v.(*Store); ok {
+			s.buster = fn
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCache(t *testing.T) {
+	s := new(Store)
+	c := libcache.LRU.New(0)
+	opt := SetCache(c)
+	opt.Apply(s)
+	assert.Equal(t, c, s.cache)
+}
+
+func TestSetTTL(t *testing.T) {
+	s := new(Store)
+	opt := SetTTL(time.Second)
+	opt.Apply(s)
+	assert.Equal(t, time.Second, s.ttl)
+}
+
+func TestSetCacheBuster(t *testing.T) {
+	s := new(Store)
+	opt := SetCacheBuster(func(string) bool { return true })
+	opt.Apply(s)
+	assert.NotNil(t, s.buster)
+}
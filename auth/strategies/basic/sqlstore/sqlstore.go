@@ -0,0 +1,101 @@
+// Package sqlstore provides a database/sql backed user store,
+// to look up users password hash for the basic authentication strategies.
+// Store.Lookup is a basic.UserLookup, compatible with the argon2 and scrypt strategies.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+// User represents a user row returned by the store query.
+type User struct {
+	Name         string
+	ID           string
+	PasswordHash string
+}
+
+// Store look up users using a parameterized SQL query,
+// and optionally caches the results.
+type Store struct {
+	db     *sql.DB
+	query  string
+	cache  auth.Cache
+	ttl    time.Duration
+	buster func(userName string) bool
+}
+
+// Lookup return the user password hash and info by user name,
+// basic.ErrInvalidCredentials returned when the user does not exist.
+func (s *Store) Lookup(ctx context.Context, userName string) (string, auth.Info, error) {
+	u, err := s.user(ctx, userName)
+	if err != nil {
+		return "", nil, err
+	}
+	return u.PasswordHash, auth.NewUserInfo(u.Name, u.ID, nil, nil), nil
+}
+
+func (s *Store) user(ctx context.Context, userName string) (User, error) {
+	if s.cache != nil && s.buster != nil && s.buster(userName) {
+		s.cache.Delete(userName)
+	}
+
+	if s.cache != nil {
+		if v, ok := s.cache.Load(userName); ok {
+			u, ok := v.(User)
+			if !ok {
+				return User{}, auth.NewTypeError("strategies/basic/sqlstore:", User{}, v)
+			}
+			return u, nil
+		}
+	}
+
+	u := User{Name: userName}
+	err := s.db.QueryRowContext(ctx, s.query, userName).Scan(&u.PasswordHash, &u.ID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return User{}, basic.ErrInvalidCredentials
+	case err != nil:
+		return User{}, fmt.Errorf("strategies/basic/sqlstore: %w", err)
+	}
+
+	if s.cache != nil {
+		s.cache.StoreWithTTL(userName, u, s.ttl)
+	}
+
+	return u, nil
+}
+
+// Invalidate removes the cached user, e.g after the user password changed.
+func (s *Store) Invalidate(userName string) {
+	if s.cache != nil {
+		s.cache.Delete(userName)
+	}
+}
+
+// New return new Store, that look up users using the query,
+// query must select the password hash and user id columns in order,
+// and accept the user name as the only parameter e.g
+//
+// 		SELECT password_hash, uid FROM users WHERE username = $1
+//
+// The parameter placeholder depends on the database driver.
+func New(db *sql.DB, query string, opts ...auth.Option) *Store {
+	s := new(Store)
+	s.db = db
+	s.query = query
+	s.ttl = time.Minute * 5
+
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	return s
+}
@@ -0,0 +1,170 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+)
+
+const query = "SELECT password_hash, uid FROM users WHERE username = $1"
+
+func TestLookup(t *testing.T) {
+	db, _ := openTestDB(t, map[string][2]string{"alice": {"hash", "1"}})
+
+	table := []struct {
+		name        string
+		user        string
+		expectedErr error
+	}{
+		{name: "it return user hash and info", user: "alice"},
+		{name: "it return invalid credentials when user not found", user: "eve", expectedErr: basic.ErrInvalidCredentials},
+		{name: "it return error when query fails", user: "fail", expectedErr: errQuery},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, info, err := New(db, query).Lookup(context.TODO(), tt.user)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "hash", hash)
+			assert.Equal(t, "alice", info.GetUserName())
+			assert.Equal(t, "1", info.GetID())
+		})
+	}
+}
+
+func TestLookupCache(t *testing.T) {
+	users := map[string][2]string{"alice": {"old", "1"}}
+	db, fake := openTestDB(t, users)
+
+	stale := false
+	s := New(db, query,
+		SetCache(libcache.LRU.New(0)),
+		SetCacheBuster(func(string) bool { return stale }),
+	)
+
+	lookup := func() string {
+		hash, _, err := s.Lookup(context.TODO(), "alice")
+		assert.NoError(t, err)
+		return hash
+	}
+
+	// Round #1 -- cached after the first lookup.
+	assert.Equal(t, "old", lookup())
+	fake.set("alice", "new", "1")
+	assert.Equal(t, "old", lookup())
+	assert.Equal(t, 1, fake.count())
+
+	// Round #2 -- cache buster forces a new lookup.
+	stale = true
+	assert.Equal(t, "new", lookup())
+	stale = false
+	assert.Equal(t, 2, fake.count())
+
+	// Round #3 -- invalidate removes the cached user.
+	fake.set("alice", "newer", "1")
+	s.Invalidate("alice")
+	assert.Equal(t, "newer", lookup())
+	assert.Equal(t, 3, fake.count())
+}
+
+var errQuery = errors.New("query failed")
+
+// fakeDriver implements a minimal database/sql driver,
+// serving the users table rows by user name.
+type fakeDriver struct {
+	mu      sync.Mutex
+	users   map[string][2]string
+	queries int
+}
+
+func (d *fakeDriver) set(user, hash, id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.users[user] = [2]string{hash, id}
+}
+
+func (d *fakeDriver) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queries
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt(c), nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return 1 }
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries++
+
+	user, _ := args[0].(string)
+	if user == "fail" {
+		return nil, errQuery
+	}
+
+	rows := &fakeRows{}
+	if v, ok := s.d.users[user]; ok {
+		rows.values = [][]driver.Value{{v[0], v[1]}}
+	}
+	return rows, nil
+}
+
+type fakeRows struct{ values [][]driver.Value }
+
+func (r *fakeRows) Columns() []string { return []string{"password_hash", "uid"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+var drivers int
+
+func openTestDB(tb testing.TB, users map[string][2]string) (*sql.DB, *fakeDriver) {
+	d := &fakeDriver{users: users}
+	drivers++
+	name := "sqlstore-" + strconv.Itoa(drivers)
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return db, d
+}
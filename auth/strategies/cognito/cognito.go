@@ -0,0 +1,119 @@
+// Package cognito provide auth strategy to authenticate,
+// incoming HTTP requests using AWS Cognito user pool access or id tokens.
+// See https://docs.aws.amazon.com/cognito/latest/developerguide/amazon-cognito-user-pools-using-tokens-verifying-a-jwt.html.
+package cognito
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+)
+
+var (
+	// ErrInvalidClientID is returned by Authenticate Strategy method,
+	// when the token issued to another app client.
+	ErrInvalidClientID = errors.New("strategies/cognito: Token issued to another app client")
+
+	// ErrInvalidTokenUse is returned by Authenticate Strategy method,
+	// when the token is neither an access nor an id token.
+	ErrInvalidTokenUse = errors.New("strategies/cognito: Invalid token_use claim")
+)
+
+// Claims represents cognito user pool access and id token claims.
+// Claims implements oauth2.ClaimsResolver.
+type Claims struct {
+	*claims.Standard
+	ClientID        string   `json:"client_id,omitempty"`
+	TokenUse        string   `json:"token_use"`
+	Username        string   `json:"username,omitempty"`
+	CognitoUsername string   `json:"cognito:username,omitempty"`
+	Groups          []string `json:"cognito:groups,omitempty"`
+	Email           string   `json:"email,omitempty"`
+	clientID        string
+}
+
+// New return's a new Claims as oauth2.ClaimsResolver.
+func (c Claims) New() oauth2.ClaimsResolver {
+	return &Claims{Standard: new(claims.Standard), clientID: c.clientID}
+}
+
+// Verify c claims, where access tokens client_id claim,
+// and id tokens aud claim must match the app client id.
+func (c Claims) Verify(opts claims.VerifyOptions) error {
+	if err := c.Standard.Verify(opts); err != nil {
+		return err
+	}
+
+	switch c.TokenUse {
+	case "access":
+		if c.ClientID != c.clientID {
+			return ErrInvalidClientID
+		}
+	case "id":
+		for _, aud := range c.Audience {
+			if aud == c.clientID {
+				return nil
+			}
+		}
+		return ErrInvalidClientID
+	default:
+		return ErrInvalidTokenUse
+	}
+
+	return nil
+}
+
+// GetExpiresAt return's c.ExpiresAt.
+func (c Claims) GetExpiresAt() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*c.ExpiresAt)
+}
+
+// GetScope return's c.Scope.
+func (c Claims) GetScope() []string {
+	return c.Scope.Split()
+}
+
+// Resolve return's user info from c, where the id is the subject,
+// and groups are the cognito user pool groups.
+func (c Claims) Resolve() auth.Info {
+	name := c.Username
+	if c.TokenUse == "id" {
+		name = c.CognitoUsername
+	}
+
+	ext := auth.Extensions{}
+	ext.Set("token_use", c.TokenUse)
+	if len(c.Email) > 0 {
+		ext.Set("email", c.Email)
+	}
+
+	return auth.NewUserInfo(name, c.Subject, c.Groups, ext)
+}
+
+// New return strategy authenticate request using cognito user pool tokens,
+// issued by the user pool in region to the app client id.
+//
+// The opts passed to the oauth2 jwt strategy,
+// use jwt.SetHTTPClient e.g. to override the defaults.
+func New(region, userPoolID, clientID string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	iss := "https://cognito-idp." + region + ".amazonaws.com/" + userPoolID
+	return newStrategy(iss+"/.well-known/jwks.json", iss, clientID, c, opts...)
+}
+
+func newStrategy(addr, iss, clientID string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	opts = append([]auth.Option{
+		jwt.SetClaimResolver(&Claims{clientID: clientID}),
+		jwt.SetVerifyOptions(claims.VerifyOptions{
+			Issuer: iss,
+		}),
+	}, opts...)
+
+	return jwt.New(addr, c, opts...)
+}
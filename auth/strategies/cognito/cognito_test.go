@@ -0,0 +1,125 @@
+package cognito
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+const issuer = "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_TEST"
+
+func TestCognito(t *testing.T) {
+	srv := mockJWKSServer(t)
+	defer srv.Close()
+
+	table := []struct {
+		name        string
+		file        string
+		expectedErr string
+		info        auth.Info
+		scopes      []string
+	}{
+		{
+			name: "it return user info when access token valid",
+			file: "access_token",
+			info: auth.NewUserInfo(
+				"jdoe",
+				"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+				[]string{"admin", "dev"},
+				auth.Extensions{"token_use": {"access"}},
+			),
+			scopes: []string{"aws.cognito.signin.user.admin", "openid"},
+		},
+		{
+			name: "it return user info when id token valid",
+			file: "id_token",
+			info: auth.NewUserInfo(
+				"jdoe",
+				"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+				[]string{"admin", "dev"},
+				auth.Extensions{"token_use": {"id"}, "email": {"jdoe@example.com"}},
+			),
+		},
+		{
+			name:        "it return error when access token issued to another client",
+			file:        "invalid_client_id",
+			expectedErr: ErrInvalidClientID.Error(),
+		},
+		{
+			name:        "it return error when id token intended for another client",
+			file:        "invalid_audience",
+			expectedErr: ErrInvalidClientID.Error(),
+		},
+		{
+			name:        "it return error when token use invalid",
+			file:        "invalid_token_use",
+			expectedErr: ErrInvalidTokenUse.Error(),
+		},
+		{
+			name:        "it return error when token issued by another user pool",
+			file:        "invalid_issuer",
+			expectedErr: "issuer",
+		},
+		{
+			name:        "it return error when token expired",
+			file:        "expired",
+			expectedErr: "expired",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStrategy(srv.URL, issuer, "client", libcache.LRU.New(0))
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+readFile(t, tt.file))
+
+			info, err := s.Authenticate(r.Context(), r)
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.scopes, token.GetNamedScopes(info))
+			info.GetExtensions().Del("x-go-guardian-scopes")
+			assert.Equal(t, tt.info, info)
+		})
+	}
+}
+
+func TestClaimsExpiresAt(t *testing.T) {
+	c := new(Claims).New().(*Claims)
+	assert.True(t, c.GetExpiresAt().IsZero())
+
+	exp := time.Unix(4102444800, 0)
+	c.ExpiresAt = new(claims.Time)
+	*c.ExpiresAt = claims.Time(exp)
+	assert.Equal(t, exp, c.GetExpiresAt())
+}
+
+func readFile(tb testing.TB, file string) string {
+	b, err := ioutil.ReadFile("./testdata/" + file)
+	if err != nil {
+		tb.Fatalf("Failed to read testdata file Err: %s", err)
+	}
+	return string(b)
+}
+
+func mockJWKSServer(tb testing.TB) *httptest.Server {
+	body := readFile(tb, "jwks.json")
+	h := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+	return httptest.NewServer(http.HandlerFunc(h))
+}
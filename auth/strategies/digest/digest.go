@@ -10,12 +10,21 @@ import (
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/shaj13/go-guardian/v2/auth"
 )
 
-// ErrInvalidResponse is returned by Strategy when client authz response does not match server hash.
-var ErrInvalidResponse = errors.New("strategies/digest: Invalid Response")
+var (
+	// ErrInvalidResponse is returned by Strategy when client authz response does not match server hash.
+	ErrInvalidResponse = errors.New("strategies/digest: Invalid Response")
+
+	// ErrReplayedRequest is returned by Strategy when client authz nonce count,
+	// does not exceed the last nonce count used with the same nonce.
+	ErrReplayedRequest = errors.New("strategies/digest: Nonce count has already been used")
+)
 
 // FetchUser a callback function to return the user password and user info.
 type FetchUser func(userName string) (string, auth.Info, error)
@@ -26,6 +35,7 @@ type Digest struct {
 	chash crypto.Hash
 	c     auth.Cache
 	h     Header
+	mu    sync.Mutex
 }
 
 // Authenticate user request and returns user info, Otherwise error.
@@ -49,10 +59,6 @@ func (d *Digest) Authenticate(ctx context.Context, r *http.Request) (auth.Info,
 		return nil, ErrInvalidResponse
 	}
 
-	if _, ok := d.c.Load(h.Nonce()); !ok {
-		return nil, ErrInvalidResponse
-	}
-
 	// validate the header values.
 	ch := d.h.Clone()
 	ch.SetNonce(h.Nonce())
@@ -61,15 +67,71 @@ func (d *Digest) Authenticate(ctx context.Context, r *http.Request) (auth.Info,
 		return nil, err
 	}
 
+	if err := d.useNonce(h.Nonce(), h.NC()); err != nil {
+		return nil, err
+	}
+
 	return info, nil
 }
 
+// nonceState represents an issued nonce, its last used count and expiry.
+type nonceState struct {
+	count   uint64
+	expires time.Time
+}
+
+// useNonce ensure the nonce issued by the server and its count,
+// greater than the last count used, to prevent replay attacks.
+func (d *Digest) useNonce(key, count string) error {
+	nc, err := strconv.ParseUint(count, 16, 64)
+	if err != nil {
+		return ErrInvalidResponse
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	v, ok := d.c.Load(key)
+	if !ok {
+		return ErrInvalidResponse
+	}
+
+	n, _ := v.(nonceState)
+	if nc <= n.count {
+		return ErrReplayedRequest
+	}
+
+	n.count = nc
+
+	// keep the nonce original expiry, to not extend its lifetime on each use.
+	if n.expires.IsZero() {
+		d.c.Store(key, n)
+		return nil
+	}
+
+	ttl := time.Until(n.expires)
+	if ttl <= 0 {
+		d.c.Delete(key)
+		return ErrInvalidResponse
+	}
+
+	d.c.StoreWithTTL(key, n, ttl)
+	return nil
+}
+
 // GetChallenge returns string indicates the authentication scheme.
 // Typically used to adds a HTTP WWW-Authenticate header.
+// The nonce expires after the cache default TTL, when the cache exposes it e.g libcache.
 func (d *Digest) GetChallenge() string {
 	h := d.h.Clone()
 	str := h.WWWAuthenticate()
-	d.c.Store(h.Nonce(), struct{}{})
+
+	n := nonceState{}
+	if c, ok := d.c.(interface{ TTL() time.Duration }); ok && c.TTL() > 0 {
+		n.expires = time.Now().Add(c.TTL())
+	}
+
+	d.c.Store(h.Nonce(), n)
 	return str
 }
 
@@ -2,9 +2,14 @@
 package digest
 
 import (
+	"crypto"
+	_ "crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/shaj13/libcache"
 	_ "github.com/shaj13/libcache/lru"
@@ -58,6 +63,84 @@ func TestStartegy(t *testing.T) {
 	}
 }
 
+func TestStrategyChallengeResponse(t *testing.T) {
+	fn := func(userName string) (string, auth.Info, error) {
+		if userName != "admin" {
+			return "", nil, fmt.Errorf("Invalid user")
+		}
+		return "secret", auth.NewDefaultUser("admin", "1", nil, nil), nil
+	}
+
+	table := []struct {
+		name      string
+		hash      crypto.Hash
+		algorithm string
+	}{
+		{
+			name:      "md5",
+			hash:      crypto.MD5,
+			algorithm: "md5",
+		},
+		{
+			name:      "sha256",
+			hash:      crypto.SHA256,
+			algorithm: "SHA-256",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New(fn, libcache.LRU.New(0), SetHash(tt.hash, tt.algorithm))
+			challenge := make(Header)
+			err := challenge.Parse(d.GetChallenge())
+			assert.NoError(t, err)
+
+			authenticate := func(nc, password string) error {
+				r := httptest.NewRequest("GET", "/protected?q=1", nil)
+				authz := clientResponse(tt.hash, challenge, r, "admin", password, nc)
+				r.Header.Set("Authorization", authz)
+				_, err := d.Authenticate(r.Context(), r)
+				return err
+			}
+
+			assert.Error(t, authenticate("00000001", "wrong"))
+			assert.NoError(t, authenticate("00000001", "secret"))
+			assert.Equal(t, ErrReplayedRequest, authenticate("00000001", "secret"))
+			assert.NoError(t, authenticate("00000002", "secret"))
+
+			challenge.SetNonce("unknown")
+			assert.Equal(t, ErrInvalidResponse, authenticate("00000003", "secret"))
+		})
+	}
+}
+
+func TestStrategyNonceExpiry(t *testing.T) {
+	fn := func(userName string) (string, auth.Info, error) {
+		return "secret", auth.NewDefaultUser("admin", "1", nil, nil), nil
+	}
+
+	cache := libcache.LRU.New(0)
+	cache.SetTTL(time.Millisecond * 100)
+	d := New(fn, cache)
+	challenge := make(Header)
+	assert.NoError(t, challenge.Parse(d.GetChallenge()))
+
+	authenticate := func(nc string) error {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", clientResponse(crypto.MD5, challenge, r, "admin", "secret", nc))
+		_, err := d.Authenticate(r.Context(), r)
+		return err
+	}
+
+	assert.NoError(t, authenticate("00000001"))
+	time.Sleep(time.Millisecond * 60)
+	assert.NoError(t, authenticate("00000002"))
+
+	// the nonce used within its lifetime, does not extend it.
+	time.Sleep(time.Millisecond * 60)
+	assert.Equal(t, ErrInvalidResponse, authenticate("00000003"))
+}
+
 func BenchmarkStrategy(b *testing.B) {
 	authz := `Digest username="a", realm="t", nonce="1", uri="/", cnonce="1=", nc=00000001, qop=auth, response="22cf307b29e6318dafba1fc1d564fc12", opaque="1", algorithm="md5"`
 	fn := func(userName string) (string, auth.Info, error) {
@@ -73,13 +156,42 @@ func BenchmarkStrategy(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
 			_, err := strategy.Authenticate(r.Context(), r)
-			if err != nil {
+			// the nonce count verified last, after the response hash.
+			if err != nil && err != ErrReplayedRequest {
 				b.Error(err)
 			}
 		}
 	})
 }
 
+// clientResponse compute authorization header as a user agent would do, from server challenge.
+func clientResponse(h crypto.Hash, challenge Header, r *http.Request, user, password, nc string) string {
+	hash := func(str string) string {
+		hh := h.New()
+		_, _ = hh.Write([]byte(str))
+		return hex.EncodeToString(hh.Sum(nil))
+	}
+
+	cnonce := "0a4f113b"
+	ha1 := hash(user + ":" + challenge.Realm() + ":" + password)
+	ha2 := hash(r.Method + ":" + r.RequestURI)
+	resp := hash(ha1 + ":" + challenge.Nonce() + ":" + nc + ":" + cnonce + ":" + challenge.QOP() + ":" + ha2)
+
+	return fmt.Sprintf(
+		`Digest username="%s", realm="%s", nonce="%s", uri="%s", cnonce="%s", nc=%s, qop=%s, response="%s", opaque="%s", algorithm=%s`,
+		user,
+		challenge.Realm(),
+		challenge.Nonce(),
+		r.RequestURI,
+		cnonce,
+		nc,
+		challenge.QOP(),
+		resp,
+		challenge.Opaque(),
+		challenge.Algorithm(),
+	)
+}
+
 func testDigest(fn FetchUser) auth.Strategy {
 	opaque := SetOpaque("1")
 	realm := SetRealm("t")
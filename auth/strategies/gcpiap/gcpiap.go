@@ -0,0 +1,91 @@
+// Package gcpiap provide auth strategy to authenticate,
+// incoming HTTP requests behind Google Cloud Identity-Aware Proxy (IAP),
+// using the signed JWT IAP injects in the X-Goog-IAP-JWT-Assertion header.
+// See https://cloud.google.com/iap/docs/signed-headers-howto.
+package gcpiap
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+const (
+	// Header is the request header IAP stores the signed JWT in.
+	Header = "X-Goog-IAP-JWT-Assertion"
+	// Issuer is the IAP JWT issuer.
+	Issuer = "https://cloud.google.com/iap"
+	// KeysAddress is the Google IAP public keys JWKS address.
+	KeysAddress = "https://www.gstatic.com/iap/verify/public_key-jwk"
+)
+
+// Claims represents IAP JWT claims.
+// Claims implements oauth2.ClaimsResolver.
+type Claims struct {
+	*claims.Standard
+	Email        string `json:"email"`
+	HostedDomain string `json:"hd,omitempty"`
+}
+
+// New return's a new Claims as oauth2.ClaimsResolver.
+func (c Claims) New() oauth2.ClaimsResolver {
+	return &Claims{Standard: new(claims.Standard)}
+}
+
+// Verify c claims, IAP JWT must include exp, sub and email claims.
+func (c Claims) Verify(opts claims.VerifyOptions) error {
+	if c.ExpiresAt == nil || len(c.Subject) == 0 || len(c.Email) == 0 {
+		return errors.New("strategies/gcpiap: IAP JWT missing exp, sub or email claim")
+	}
+	return c.Standard.Verify(opts)
+}
+
+// GetExpiresAt return's c.ExpiresAt.
+func (c Claims) GetExpiresAt() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return time.Time(*c.ExpiresAt)
+}
+
+// Resolve return's user info from c, where the user name is the email,
+// and id is the subject.
+func (c Claims) Resolve() auth.Info {
+	ext := auth.Extensions{}
+	if len(c.HostedDomain) > 0 {
+		ext.Set("hd", c.HostedDomain)
+	}
+	return auth.NewUserInfo(c.Email, c.Subject, nil, ext)
+}
+
+// New return strategy authenticate request using Google Cloud IAP signed JWT,
+// the JWT must be signed by Google IAP keys, issued by IAP and intended to the audience.
+// The keys cached and refreshed following the JWKS response Cache-Control header.
+//
+// The audience string format depends on the backend e.g
+// /projects/PROJECT_NUMBER/global/backendServices/SERVICE_ID,
+// or /projects/PROJECT_NUMBER/apps/PROJECT_ID for App Engine.
+//
+// The opts passed to the oauth2 jwt strategy,
+// use jwt.SetHTTPClient and jwt.SetVerifyOptions e.g. to override the defaults.
+func New(audience string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	return newStrategy(KeysAddress, audience, c, opts...)
+}
+
+func newStrategy(addr, audience string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	opts = append([]auth.Option{
+		token.SetParser(token.XHeaderParser(Header)),
+		jwt.SetClaimResolver(new(Claims)),
+		jwt.SetVerifyOptions(claims.VerifyOptions{
+			Audience: []string{audience},
+			Issuer:   Issuer,
+		}),
+	}, opts...)
+
+	return jwt.New(addr, c, opts...)
+}
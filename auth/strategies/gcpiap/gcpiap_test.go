@@ -0,0 +1,123 @@
+package gcpiap
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+)
+
+const audience = "/projects/123456/global/backendServices/987654"
+
+func TestIAP(t *testing.T) {
+	calls := 0
+	srv := mockKeysServer(t, &calls)
+	defer srv.Close()
+	s := newStrategy(srv.URL, audience, libcache.LRU.New(0))
+
+	table := []struct {
+		name        string
+		file        string
+		header      string
+		expectedErr string
+	}{
+		{
+			name:        "it return error when header missing",
+			expectedErr: "Invalid token",
+		},
+		{
+			name:        "it return error when header is not a jwt",
+			header:      Header,
+			expectedErr: "compact JWS format",
+		},
+		{
+			name:        "it return error when jwt expired",
+			file:        "expired",
+			header:      Header,
+			expectedErr: "expired",
+		},
+		{
+			name:        "it return error when jwt intended for another audience",
+			file:        "invalid_audience",
+			header:      Header,
+			expectedErr: "audience",
+		},
+		{
+			name:        "it return error when jwt issued by another issuer",
+			file:        "invalid_issuer",
+			header:      Header,
+			expectedErr: "issuer",
+		},
+		{
+			name:        "it return error when jwt sent in authorization header",
+			file:        "valid",
+			header:      "Authorization",
+			expectedErr: "Invalid token",
+		},
+		{
+			name:   "it return user info when jwt valid",
+			file:   "valid",
+			header: Header,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			if len(tt.header) > 0 {
+				r.Header.Set(tt.header, readFile(t, tt.file))
+			}
+
+			info, err := s.Authenticate(r.Context(), r)
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "user@example.com", info.GetUserName())
+			assert.Equal(t, "accounts.google.com:1234567890", info.GetID())
+			assert.Equal(t, "example.com", info.GetExtensions().Get("hd"))
+		})
+	}
+
+	// the valid fixture expires at 2100-01-01.
+	exp := Claims{}.New().(*Claims)
+	exp.ExpiresAt = new(claims.Time)
+	*exp.ExpiresAt = claims.Time(time.Unix(4102444800, 0))
+	assert.Equal(t, time.Unix(4102444800, 0), exp.GetExpiresAt())
+
+	// keys cached as long as the Cache-Control max-age.
+	assert.Equal(t, 1, calls)
+}
+
+func readFile(tb testing.TB, file string) string {
+	if len(file) == 0 {
+		return "invalid"
+	}
+
+	b, err := ioutil.ReadFile("./testdata/" + file)
+	if err != nil {
+		tb.Fatalf("Failed to read testdata file Err: %s", err)
+	}
+
+	return string(b)
+}
+
+func mockKeysServer(tb testing.TB, calls *int) *httptest.Server {
+	body := readFile(tb, "jwks.json")
+	h := func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_, _ = w.Write([]byte(body))
+	}
+	return httptest.NewServer(http.HandlerFunc(h))
+}
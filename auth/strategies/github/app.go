@@ -0,0 +1,34 @@
+package github
+
+import (
+	"crypto/rsa"
+	"strconv"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// IssueAppToken issue a GitHub App JWT, signed by the app private key,
+// and valid for 10 minutes, the maximum allowed by GitHub.
+// The token authenticates the app itself to the GitHub API,
+// e.g to create installation access tokens.
+func IssueAppToken(appID int64, key *rsa.PrivateKey) (string, error) {
+	sig, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// backdate iat to allow for clock drift.
+	now := time.Now()
+	claims := jwt.Claims{
+		Issuer:   strconv.FormatInt(appID, 10),
+		IssuedAt: jwt.NewNumericDate(now.Add(-time.Minute)),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Minute * 10)),
+	}
+
+	return jwt.Signed(sig).Claims(claims).CompactSerialize()
+}
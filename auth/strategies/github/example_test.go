@@ -0,0 +1,45 @@
+package github_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/github"
+)
+
+func ExampleNewPAT() {
+	srv := GitHubAPI()
+	defer srv.Close()
+
+	opts := []auth.Option{
+		github.SetAddress(srv.URL),
+		github.SetHTTPClient(srv.Client()),
+	}
+
+	strategy := github.NewPAT([]string{"acme"}, libcache.LRU.New(0), opts...)
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer <personal-access-token>")
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), info.GetGroups(), err)
+	// Output:
+	// octocat [acme] <nil>
+}
+
+func GitHubAPI() *httptest.Server {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"login":"octocat","id":1}`))
+		case "/orgs/acme/members/octocat":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(h))
+}
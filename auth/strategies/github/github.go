@@ -0,0 +1,168 @@
+// Package github provide auth strategy to authenticate,
+// incoming HTTP requests using a GitHub personal access token or OAuth token.
+// The token is verified against the GitHub REST API,
+// and the user is optionally restricted to members of allowed organizations.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// DefaultAddress is the GitHub REST API address.
+const DefaultAddress = "https://api.github.com"
+
+// ErrNotOrgMember is returned by Authenticate Strategy method,
+// when the user is not a member of any of the allowed organizations.
+var ErrNotOrgMember = errors.New("strategies/github: User is not a member of an allowed organization")
+
+type user struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+func (e apiError) Error() string {
+	return e.Message
+}
+
+type github struct {
+	requester *internal.Requester
+	orgs      []string
+}
+
+func (g *github) authenticate(ctx context.Context, r *http.Request, tokenstr string) (auth.Info, time.Time, error) { //nolint:lll
+	t := time.Time{}
+	u := new(user)
+	apierr := new(apiError)
+	f := func(r *http.Request) {
+		r.Header.Set("Authorization", string(token.Bearer)+" "+tokenstr)
+	}
+	fail := func(err error) (auth.Info, time.Time, error) {
+		return nil, t, fmt.Errorf("strategies/github: %w", err)
+	}
+
+	//nolint:bodyclose
+	resp, err := g.requester.DoWithf(ctx, f, nil, u, apierr)
+
+	switch {
+	case err != nil:
+		return fail(err)
+	case resp.StatusCode != http.StatusOK && len(apierr.Message) > 0:
+		return fail(apierr)
+	case resp.StatusCode != http.StatusOK:
+		return fail(fmt.Errorf("GitHub API returned %v status code", resp.StatusCode))
+	}
+
+	groups := []string{}
+	for _, org := range g.orgs {
+		ok, err := g.member(ctx, f, org, u.Login)
+		if err != nil {
+			return fail(err)
+		}
+		if ok {
+			groups = append(groups, org)
+		}
+	}
+
+	if len(g.orgs) > 0 && len(groups) == 0 {
+		return nil, t, ErrNotOrgMember
+	}
+
+	ext := auth.Extensions{}
+	if len(u.Name) > 0 {
+		ext.Set("name", u.Name)
+	}
+	if len(u.Email) > 0 {
+		ext.Set("email", u.Email)
+	}
+
+	id := strconv.FormatInt(u.ID, 10)
+	return auth.NewUserInfo(u.Login, id, groups, ext), t, nil
+}
+
+// member reports whether login is a member of org.
+// GitHub respond with 204 No Content to members, and 404 or 302 otherwise.
+func (g *github) member(ctx context.Context, f func(*http.Request), org, login string) (bool, error) {
+	addr := g.requester.Addr + "/orgs/" + url.PathEscape(org) + "/members/" + url.PathEscape(login)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr, nil)
+	if err != nil {
+		return false, err
+	}
+
+	f(req)
+	if g.requester.AdditionalData != nil {
+		g.requester.AdditionalData(req)
+	}
+
+	resp, err := g.requester.Client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("Failed to send the HTTP request, Method: GET, URL: %s, Err: %w", addr, err)
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return true, nil
+	case http.StatusNotFound, http.StatusFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("GitHub API returned %v status code for %s membership", resp.StatusCode, org)
+	}
+}
+
+// GetAuthenticateFunc return function to authenticate request using GitHub token,
+// for users who are members of at least one of the given organizations,
+// or any user when orgs is empty.
+// The returned function typically used with the token strategy.
+func GetAuthenticateFunc(orgs []string, opts ...auth.Option) token.AuthenticateFunc {
+	return newGitHub(orgs, opts...).authenticate
+}
+
+// NewPAT return strategy authenticate request using GitHub personal access token or OAuth token.
+// The authenticated user info groups holds the allowed organizations the user is a member of.
+//
+// NewPAT is similar to:
+//
+// 		fn := github.GetAuthenticateFunc(orgs, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func NewPAT(orgs []string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	fn := GetAuthenticateFunc(orgs, opts...)
+	return token.New(fn, c, opts...)
+}
+
+func newGitHub(orgs []string, opts ...auth.Option) *github {
+	r := internal.NewRequester(DefaultAddress)
+	r.Endpoint = "/user"
+	r.KeepUnmarshalling = true
+	r.Method = http.MethodGet
+	r.SetHeader("Accept", "application/vnd.github.v3+json")
+
+	g := new(github)
+	g.requester = r
+	g.orgs = orgs
+
+	for _, opt := range opts {
+		opt.Apply(g)
+		opt.Apply(g.requester)
+	}
+
+	return g
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestGitHub(t *testing.T) {
+	srv := mockGitHubAPI(t)
+	defer srv.Close()
+
+	table := []struct {
+		name        string
+		token       string
+		orgs        []string
+		expectedErr string
+		info        auth.Info
+	}{
+		{
+			name:        "it return error when token invalid",
+			token:       "invalid",
+			expectedErr: "Bad credentials",
+		},
+		{
+			name:  "it return user info when orgs empty",
+			token: "valid",
+			info:  auth.NewUserInfo("octocat", "1", []string{}, auth.Extensions{"name": {"The Octocat"}}),
+		},
+		{
+			name:  "it return user info with member orgs as groups",
+			token: "valid",
+			orgs:  []string{"github", "acme", "golang"},
+			info:  auth.NewUserInfo("octocat", "1", []string{"github", "golang"}, auth.Extensions{"name": {"The Octocat"}}),
+		},
+		{
+			name:        "it return error when user not a member of allowed orgs",
+			token:       "valid",
+			orgs:        []string{"acme"},
+			expectedErr: ErrNotOrgMember.Error(),
+		},
+		{
+			name:        "it return error when membership check fails",
+			token:       "valid",
+			orgs:        []string{"broken"},
+			expectedErr: "500 status code",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := GetAuthenticateFunc(tt.orgs, SetAddress(srv.URL), SetHTTPClient(srv.Client()))
+			info, _, err := fn(context.TODO(), nil, tt.token)
+
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.info, info)
+		})
+	}
+}
+
+func TestIssueAppToken(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	str, err := IssueAppToken(12345, key)
+	assert.NoError(t, err)
+
+	tk, err := jwt.ParseSigned(str)
+	assert.NoError(t, err)
+
+	claims := jwt.Claims{}
+	assert.NoError(t, tk.Claims(key.Public(), &claims))
+	assert.Equal(t, "12345", claims.Issuer)
+	assert.NoError(t, claims.Validate(jwt.Expected{Issuer: "12345", Time: time.Now()}))
+	assert.True(t, claims.Expiry.Time().Before(time.Now().Add(time.Minute*10+time.Second)))
+}
+
+func mockGitHubAPI(tb testing.TB) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"login":"octocat","id":1,"name":"The Octocat"}`))
+	})
+
+	mux.HandleFunc("/orgs/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/github/members/octocat", "/orgs/golang/members/octocat":
+			w.WriteHeader(http.StatusNoContent)
+		case "/orgs/broken/members/octocat":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
@@ -0,0 +1,30 @@
+package github
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+// SetAddress sets GitHub API address, commonly used with GitHub Enterprise.
+// e.g https://github.example.com/api/v3
+func SetAddress(addr string) auth.Option {
+	return internal.SetRequesterAddress(addr)
+}
+
+// SetHTTPClient sets underlying http client.
+func SetHTTPClient(c *http.Client) auth.Option {
+	return internal.SetRequesterHTTPClient(c)
+}
+
+// SetTLSConfig sets underlying http client tls.
+func SetTLSConfig(tls *tls.Config) auth.Option {
+	return internal.SetRequesterTLSConfig(tls)
+}
+
+// SetClientTransport sets underlying http client transport.
+func SetClientTransport(rt http.RoundTripper) auth.Option {
+	return internal.SetRequesterClientTransport(rt)
+}
@@ -0,0 +1,31 @@
+package hmac_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/hmac"
+)
+
+func Example() {
+	resolver := hmac.KeyResolverFunc(func(ctx context.Context, keyID string) ([]byte, auth.Info, error) {
+		// typically looked up from DB.
+		return []byte("secret"), auth.NewDefaultUser("machine", "1", nil, nil), nil
+	})
+
+	strategy := hmac.New(resolver)
+
+	// client side.
+	r, _ := http.NewRequest("POST", "https://api.example.com/v1/orders", strings.NewReader(`{"item":"book"}`))
+	_ = hmac.Sign(r, "AKID", []byte("secret"))
+
+	// server side.
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+
+	// Output:
+	// machine <nil>
+}
@@ -0,0 +1,279 @@
+// Package hmac provides authentication strategy,
+// to authenticate HTTP requests signed by the client using HMAC-SHA256,
+// over a canonical form of the request (method, path, query, signed headers and body hash),
+// similar to AWS Signature Version 4.
+//
+// Authorization header format:
+//
+// 		Authorization: HMAC-SHA256 Credential=<key-id>,SignedHeaders=date;host,Signature=<hex>
+//
+package hmac
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// Scheme is the Authorization header scheme.
+const Scheme = "HMAC-SHA256"
+
+var (
+	// ErrMissingSignature is returned by Authenticate Strategy method,
+	// when the request missing or has malformed HMAC-SHA256 Authorization header.
+	ErrMissingSignature = errors.New("strategies/hmac: Request missing signature")
+
+	// ErrInvalidSignature is returned by Authenticate Strategy method,
+	// when the request signature does not match the expected signature.
+	ErrInvalidSignature = errors.New("strategies/hmac: Invalid signature")
+
+	// ErrRequestExpired is returned by Authenticate Strategy method,
+	// when the request Date header missing, unsigned, or outside the allowed clock skew.
+	ErrRequestExpired = errors.New("strategies/hmac: Request date expired or missing")
+
+	// ErrBodyTooLarge is returned by Authenticate Strategy method,
+	// when the request body exceeds the maximum body size.
+	ErrBodyTooLarge = errors.New("strategies/hmac: Request body too large")
+)
+
+// KeyResolver resolve a key id to its secret and owner info.
+type KeyResolver interface {
+	Resolve(ctx context.Context, keyID string) (secret []byte, info auth.Info, err error)
+}
+
+// KeyResolverFunc is an adapter to allow the use of ordinary functions as KeyResolver.
+type KeyResolverFunc func(ctx context.Context, keyID string) ([]byte, auth.Info, error)
+
+// Resolve calls fn(ctx, keyID).
+func (fn KeyResolverFunc) Resolve(ctx context.Context, keyID string) ([]byte, auth.Info, error) {
+	return fn(ctx, keyID)
+}
+
+type strategy struct {
+	resolver KeyResolver
+	skew     time.Duration
+	maxBody  int64
+	now      func() time.Time
+}
+
+func (s *strategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	keyID, signed, sig, err := parseAuthorization(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyDate(r, signed); err != nil {
+		return nil, err
+	}
+
+	secret, info, err := s.resolver.Resolve(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := signature(r, secret, signed, s.maxBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return nil, ErrInvalidSignature
+	}
+
+	return info, nil
+}
+
+func (s *strategy) verifyDate(r *http.Request, signed []string) error {
+	if !contains(signed, "date") {
+		return ErrRequestExpired
+	}
+
+	date, err := http.ParseTime(r.Header.Get("Date"))
+	if err != nil {
+		return ErrRequestExpired
+	}
+
+	d := s.now().Sub(date)
+	if d > s.skew || d < -s.skew {
+		return ErrRequestExpired
+	}
+
+	return nil
+}
+
+// Sign sets the request Date header when missing,
+// and the HMAC-SHA256 Authorization header signed using the secret.
+// The date and host headers always signed, in addition to the provided headers.
+func Sign(r *http.Request, keyID string, secret []byte, headers ...string) error {
+	if len(r.Header.Get("Date")) == 0 {
+		r.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	}
+
+	signed := []string{"date", "host"}
+	for _, h := range headers {
+		h = strings.ToLower(h)
+		if !contains(signed, h) {
+			signed = append(signed, h)
+		}
+	}
+	sort.Strings(signed)
+
+	sig, err := signature(r, secret, signed, 0)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Authorization", Scheme+" Credential="+keyID+
+		",SignedHeaders="+strings.Join(signed, ";")+
+		",Signature="+sig)
+
+	return nil
+}
+
+func parseAuthorization(r *http.Request) (keyID string, signed []string, sig string, err error) {
+	h := strings.TrimSpace(r.Header.Get("Authorization"))
+	if !strings.HasPrefix(h, Scheme+" ") {
+		return "", nil, "", ErrMissingSignature
+	}
+
+	for _, pair := range strings.Split(h[len(Scheme)+1:], ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			return "", nil, "", ErrMissingSignature
+		}
+
+		switch kv[0] {
+		case "Credential":
+			keyID = kv[1]
+		case "SignedHeaders":
+			signed = strings.Split(strings.ToLower(kv[1]), ";")
+		case "Signature":
+			sig = kv[1]
+		}
+	}
+
+	if len(keyID) == 0 || len(signed) == 0 || len(sig) == 0 {
+		return "", nil, "", ErrMissingSignature
+	}
+
+	return keyID, signed, sig, nil
+}
+
+// signature return hex encoded HMAC-SHA256 of the string to sign:
+//
+// 		HMAC-SHA256 \n <Date header> \n hex(sha256(canonical request))
+//
+func signature(r *http.Request, secret []byte, signed []string, maxBody int64) (string, error) {
+	creq, err := canonicalRequest(r, signed, maxBody)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(creq))
+	sts := Scheme + "\n" + r.Header.Get("Date") + "\n" + hex.EncodeToString(sum[:])
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(sts))
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// canonicalRequest return the request canonical form:
+//
+// 		method \n path \n sorted query \n name:value \n ... \n signed headers \n hex(sha256(body))
+//
+// The body read up to maxBody bytes, zero means no limit.
+func canonicalRequest(r *http.Request, signed []string, maxBody int64) (string, error) {
+	body := []byte{}
+
+	if r.Body != nil {
+		var rd io.Reader = r.Body
+		if maxBody > 0 {
+			rd = io.LimitReader(r.Body, maxBody+1)
+		}
+
+		b, err := ioutil.ReadAll(rd)
+		if err != nil {
+			return "", err
+		}
+
+		if maxBody > 0 && int64(len(b)) > maxBody {
+			return "", ErrBodyTooLarge
+		}
+
+		body = b
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	path := r.URL.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+
+	buf := new(strings.Builder)
+	buf.WriteString(r.Method + "\n")
+	buf.WriteString(path + "\n")
+	buf.WriteString(canonicalQuery(r.URL.Query()) + "\n")
+
+	for _, h := range signed {
+		v := r.Header.Get(h)
+		if h == "host" {
+			v = r.Host
+		}
+		buf.WriteString(h + ":" + strings.TrimSpace(v) + "\n")
+	}
+
+	sum := sha256.Sum256(body)
+	buf.WriteString(strings.Join(signed, ";") + "\n")
+	buf.WriteString(hex.EncodeToString(sum[:]))
+
+	return buf.String(), nil
+}
+
+func canonicalQuery(q url.Values) string {
+	// url.Values.Encode sort by key.
+	for _, v := range q {
+		sort.Strings(v)
+	}
+	return strings.Replace(q.Encode(), "+", "%20", -1)
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// New return strategy authenticate request signed using HMAC-SHA256,
+// the request key id resolved to its secret using the provided resolver.
+// By default requests with Date header more than 5 minutes apart from the server time rejected,
+// use SetMaxSkew to override it.
+// Request bodies larger than 10 MiB rejected before verification, use SetMaxBodySize to override it.
+func New(resolver KeyResolver, opts ...auth.Option) auth.Strategy {
+	s := new(strategy)
+	s.resolver = resolver
+	s.skew = time.Minute * 5
+	s.maxBody = 10 << 20
+	s.now = time.Now
+
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	return s
+}
@@ -0,0 +1,239 @@
+package hmac
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+const testDate = "Wed, 30 Dec 2020 12:00:00 GMT"
+
+var errUnknownKey = errors.New("unknown key")
+
+func resolver(ctx context.Context, keyID string) ([]byte, auth.Info, error) {
+	if keyID != "AKID" {
+		return nil, nil, errUnknownKey
+	}
+	return []byte("secret"), auth.NewDefaultUser("test", "1", nil, nil), nil
+}
+
+func newTestStrategy(t time.Time) *strategy {
+	s := New(KeyResolverFunc(resolver)).(*strategy)
+	s.now = func() time.Time { return t }
+	return s
+}
+
+func TestVectors(t *testing.T) {
+	table := []struct {
+		name      string
+		prepare   func() *http.Request
+		signed    []string
+		signature string
+	}{
+		{
+			name: "signed POST request with query and body",
+			prepare: func() *http.Request {
+				body := strings.NewReader(`{"item":"book"}`)
+				r, _ := http.NewRequest("POST", "https://api.example.com/v1/orders?b=2&a=1", body)
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			signed:    []string{"content-type", "date", "host"},
+			signature: "88777abd997906fd78293e6be2365fe2684e97606805030497082e51c4f6f4fa",
+		},
+		{
+			name: "signed GET request without body",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "https://example.com", nil)
+				return r
+			},
+			signed:    []string{"date", "host"},
+			signature: "cb1b9d7198eb1222899fbe2a2956c64b7dd638a36a9cde3efb5a489e4ef60d5f",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.prepare()
+			r.Header.Set("Date", testDate)
+
+			sig, err := signature(r, []byte("secret"), tt.signed, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.signature, sig)
+
+			r.Header.Set(
+				"Authorization",
+				"HMAC-SHA256 Credential=AKID,SignedHeaders="+strings.Join(tt.signed, ";")+",Signature="+tt.signature,
+			)
+
+			date, _ := http.ParseTime(testDate)
+			info, err := newTestStrategy(date).Authenticate(r.Context(), r)
+			assert.NoError(t, err)
+			assert.Equal(t, "test", info.GetUserName())
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	date, _ := http.ParseTime(testDate)
+
+	signed := func(method, body string) *http.Request {
+		r, _ := http.NewRequest(method, "https://api.example.com/v1/orders", strings.NewReader(body))
+		r.Header.Set("Date", testDate)
+		_ = Sign(r, "AKID", []byte("secret"))
+		return r
+	}
+
+	table := []struct {
+		name        string
+		prepare     func() *http.Request
+		now         time.Time
+		expectedErr error
+	}{
+		{
+			name: "it authenticate signed request",
+			prepare: func() *http.Request {
+				return signed("POST", "body")
+			},
+			now: date,
+		},
+		{
+			name: "it authenticate signed request within skew",
+			prepare: func() *http.Request {
+				return signed("POST", "body")
+			},
+			now: date.Add(time.Minute * 4),
+		},
+		{
+			name: "it return error when authorization header missing",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				return r
+			},
+			expectedErr: ErrMissingSignature,
+		},
+		{
+			name: "it return error when authorization header malformed",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				r.Header.Set("Authorization", "HMAC-SHA256 Credential=AKID")
+				return r
+			},
+			expectedErr: ErrMissingSignature,
+		},
+		{
+			name: "it return error when request replayed after skew",
+			prepare: func() *http.Request {
+				return signed("POST", "body")
+			},
+			now:         date.Add(time.Minute * 6),
+			expectedErr: ErrRequestExpired,
+		},
+		{
+			name: "it return error when date in the future",
+			prepare: func() *http.Request {
+				return signed("POST", "body")
+			},
+			now:         date.Add(-time.Minute * 6),
+			expectedErr: ErrRequestExpired,
+		},
+		{
+			name: "it return error when date not signed",
+			prepare: func() *http.Request {
+				r := signed("POST", "body")
+				h := strings.Replace(r.Header.Get("Authorization"), "date;", "", 1)
+				r.Header.Set("Authorization", h)
+				return r
+			},
+			now:         date,
+			expectedErr: ErrRequestExpired,
+		},
+		{
+			name: "it return error when body tampered",
+			prepare: func() *http.Request {
+				r := signed("POST", "body")
+				r.Body = ioutil.NopCloser(strings.NewReader("tampered"))
+				return r
+			},
+			now:         date,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "it return error when method tampered",
+			prepare: func() *http.Request {
+				r := signed("POST", "body")
+				r.Method = "PUT"
+				return r
+			},
+			now:         date,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name: "it return resolver error",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				r.Header.Set("Date", testDate)
+				_ = Sign(r, "unknown", []byte("secret"))
+				return r
+			},
+			now:         date,
+			expectedErr: errUnknownKey,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.prepare()
+			info, err := newTestStrategy(tt.now).Authenticate(r.Context(), r)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, "test", info.GetUserName())
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	r, _ := http.NewRequest("POST", "https://api.example.com/", strings.NewReader("body"))
+	r.Header.Set("X-Request-ID", "1")
+	err := Sign(r, "AKID", []byte("secret"), "X-Request-ID", "Date")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, r.Header.Get("Date"))
+	assert.True(t, strings.HasPrefix(
+		r.Header.Get("Authorization"),
+		"HMAC-SHA256 Credential=AKID,SignedHeaders=date;host;x-request-id,Signature=",
+	))
+
+	// body must remain readable after signing.
+	b, _ := ioutil.ReadAll(r.Body)
+	assert.Equal(t, "body", string(b))
+}
+
+func TestStrategyMaxBodySize(t *testing.T) {
+	date, _ := http.ParseTime(testDate)
+	signed := func(body string) *http.Request {
+		r, _ := http.NewRequest("POST", "https://api.example.com/orders", strings.NewReader(body))
+		r.Header.Set("Date", testDate)
+		_ = Sign(r, "AKID", []byte("secret"))
+		return r
+	}
+
+	s := newTestStrategy(date)
+	SetMaxBodySize(4).Apply(s)
+
+	r := signed("body")
+	_, err := s.Authenticate(r.Context(), r)
+	assert.NoError(t, err)
+
+	r = signed("large body")
+	_, err = s.Authenticate(r.Context(), r)
+	assert.Equal(t, ErrBodyTooLarge, err)
+}
@@ -0,0 +1,29 @@
+package hmac
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetMaxSkew sets the maximum allowed difference between the request Date header and the server time,
+// to protect against replayed requests.
+// Default Value 5 Minutes.
+func SetMaxSkew(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.skew = d
+		}
+	})
+}
+
+// SetMaxBodySize sets the maximum request body size in bytes read to verify the signature,
+// requests with larger bodies rejected with ErrBodyTooLarge. Zero means no limit.
+// Default Value 10 MiB.
+func SetMaxBodySize(n int64) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.maxBody = n
+		}
+	})
+}
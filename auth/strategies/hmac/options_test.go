@@ -0,0 +1,22 @@
+package hmac
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxSkew(t *testing.T) {
+	s := new(strategy)
+	opt := SetMaxSkew(time.Minute)
+	opt.Apply(s)
+	assert.Equal(t, time.Minute, s.skew)
+}
+
+func TestSetMaxBodySize(t *testing.T) {
+	s := new(strategy)
+	opt := SetMaxBodySize(1024)
+	opt.Apply(s)
+	assert.Equal(t, int64(1024), s.maxBody)
+}
@@ -0,0 +1,137 @@
+package httpsig
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+const (
+	// ECDSAP256SHA256 is the ECDSA using curve P-256 and SHA-256 algorithm name.
+	ECDSAP256SHA256 = "ecdsa-p256-sha256"
+	// Ed25519 is the EdDSA using curve edwards25519 algorithm name.
+	Ed25519 = "ed25519"
+	// HMACSHA256 is the HMAC using SHA-256 algorithm name.
+	HMACSHA256 = "hmac-sha256"
+)
+
+// verify the signature over the signature base using key,
+// the algorithm inferred from the key type when alg is empty.
+func verify(alg string, key interface{}, base, sig []byte) error {
+	if a := algorithm(key); len(a) == 0 || (len(alg) > 0 && alg != a) {
+		return ErrUnsupportedAlgorithm
+	}
+
+	ok := false
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if len(sig) != 64 {
+			return ErrInvalidSignature
+		}
+		sum := sha256.Sum256(base)
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		ok = ecdsa.Verify(key, sum[:], r, s)
+	case ed25519.PublicKey:
+		ok = len(key) == ed25519.PublicKeySize && ed25519.Verify(key, base, sig)
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write(base)
+		ok = hmac.Equal(mac.Sum(nil), sig)
+	}
+
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func sign(key interface{}, base []byte) ([]byte, error) {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		sum := sha256.Sum256(base)
+		r, s, err := ecdsa.Sign(rand.Reader, key, sum[:])
+		if err != nil {
+			return nil, err
+		}
+		// fixed size r || s, as defined in RFC 9421 section 3.3.4.
+		sig := make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rb):32], rb)
+		copy(sig[64-len(sb):], sb)
+		return sig, nil
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, base), nil
+	case []byte:
+		mac := hmac.New(sha256.New, key)
+		_, _ = mac.Write(base)
+		return mac.Sum(nil), nil
+	}
+
+	return nil, ErrUnsupportedAlgorithm
+}
+
+func algorithm(key interface{}) string {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() {
+			return ECDSAP256SHA256
+		}
+	case *ecdsa.PrivateKey:
+		if key.Curve == elliptic.P256() {
+			return ECDSAP256SHA256
+		}
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		return Ed25519
+	case []byte:
+		return HMACSHA256
+	}
+	return ""
+}
+
+// Sign sets the request Signature-Input and Signature headers,
+// signed under label using key over the given components, e.g "@method", "@path", "date".
+// The key must be one of *ecdsa.PrivateKey (P-256), ed25519.PrivateKey,
+// or []byte as HMAC-SHA256 shared secret.
+// The created, keyid and alg parameters always added to the signature input.
+func Sign(r *http.Request, label, keyID string, key interface{}, components ...string) error {
+	alg := algorithm(key)
+	if len(alg) == 0 {
+		return ErrUnsupportedAlgorithm
+	}
+
+	in := signatureInput{
+		params: params{
+			{key: "created", value: time.Now().Unix()},
+			{key: "keyid", value: keyID},
+			{key: "alg", value: alg},
+		},
+	}
+
+	for _, c := range components {
+		in.items = append(in.items, item{value: c})
+	}
+
+	base, err := signatureBase(r, in)
+	if err != nil {
+		return err
+	}
+
+	sig, err := sign(key, []byte(base))
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(HeaderSignatureInput, label+"="+innerList(in).serialize())
+	r.Header.Set(HeaderSignature, label+"="+serializeBareItem(sig))
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package httpsig
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// signatureInput represents the Signature-Input member value,
+// the covered components and signature parameters.
+type signatureInput innerList
+
+func (in signatureInput) param(key string) interface{} {
+	v, _ := in.params.get(key)
+	return v
+}
+
+func (in signatureInput) covers(component string) bool {
+	for _, it := range in.items {
+		if it.value == component {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureBase return the signature base as defined in RFC 9421 section 2.5.
+func signatureBase(r *http.Request, in signatureInput) (string, error) {
+	b := new(strings.Builder)
+	seen := make(map[string]struct{}, len(in.items))
+
+	for _, it := range in.items {
+		name, ok := it.value.(string)
+		if !ok || len(it.params) > 0 || name != strings.ToLower(name) {
+			return "", ErrInvalidSignatureInput
+		}
+
+		if _, ok := seen[name]; ok {
+			return "", ErrInvalidSignatureInput
+		}
+		seen[name] = struct{}{}
+
+		v, ok := componentValue(r, name)
+		if !ok {
+			return "", ErrInvalidSignatureInput
+		}
+
+		b.WriteString(serializeBareItem(name) + ": " + v + "\n")
+	}
+
+	b.WriteString(`"@signature-params": ` + innerList(in).serialize())
+
+	return b.String(), nil
+}
+
+func componentValue(r *http.Request, name string) (string, bool) {
+	if !strings.HasPrefix(name, "@") {
+		return headerValue(r, name)
+	}
+
+	switch name {
+	case "@method":
+		return r.Method, true
+	case "@authority":
+		return authority(r), true
+	case "@scheme":
+		return scheme(r), true
+	case "@target-uri":
+		return scheme(r) + "://" + authority(r) + r.URL.RequestURI(), true
+	case "@request-target":
+		return r.URL.RequestURI(), true
+	case "@path":
+		if p := r.URL.EscapedPath(); len(p) > 0 {
+			return p, true
+		}
+		return "/", true
+	case "@query":
+		return "?" + r.URL.RawQuery, true
+	}
+
+	// @query-param, @status and unknown derived components not supported.
+	return "", false
+}
+
+func headerValue(r *http.Request, name string) (string, bool) {
+	if name == "host" {
+		return authority(r), true
+	}
+
+	values, ok := r.Header[textproto.CanonicalMIMEHeaderKey(name)]
+	if !ok {
+		return "", false
+	}
+
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+
+	return strings.Join(trimmed, ", "), true
+}
+
+func authority(r *http.Request) string {
+	host := r.Host
+	if len(host) == 0 {
+		host = r.URL.Host
+	}
+	return strings.ToLower(host)
+}
+
+func scheme(r *http.Request) string {
+	switch {
+	case len(r.URL.Scheme) > 0:
+		return strings.ToLower(r.URL.Scheme)
+	case r.TLS != nil:
+		return "https"
+	}
+	return "http"
+}
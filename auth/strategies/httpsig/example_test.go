@@ -0,0 +1,35 @@
+package httpsig_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/httpsig"
+)
+
+func Example() {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	resolver := httpsig.KeyResolverFunc(func(ctx context.Context, keyID string) (interface{}, auth.Info, error) {
+		// typically looked up from DB or JWKS.
+		return pub, auth.NewDefaultUser("machine", "1", nil, nil), nil
+	})
+
+	strategy := httpsig.New(resolver)
+
+	// client side.
+	r, _ := http.NewRequest("POST", "https://api.example.com/v1/orders", strings.NewReader(`{"item":"book"}`))
+	_ = httpsig.Sign(r, "sig1", "client-key", priv, "@method", "@authority", "@path")
+
+	// server side.
+	info, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+
+	// Output:
+	// machine <nil>
+}
@@ -0,0 +1,208 @@
+// Package httpsig provides authentication strategy,
+// to authenticate HTTP requests signed by the client as defined in RFC 9421 HTTP Message Signatures.
+// The signature covers an ordered list of request headers and derived components
+// (e.g @method, @authority, @path), declared in the Signature-Input header:
+//
+// 		Signature-Input: sig1=("@method" "@authority" "@path" "date");created=1618884473;keyid="key-id"
+// 		Signature: sig1=:<base64 signature>:
+//
+// Supported algorithms are ecdsa-p256-sha256, ed25519 and hmac-sha256.
+//
+// A covered content-digest header value is part of the signature,
+// but it's not checked against the request body, which left to the caller.
+package httpsig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+const (
+	// HeaderSignatureInput is the header carrying the signature covered components and parameters.
+	HeaderSignatureInput = "Signature-Input"
+	// HeaderSignature is the header carrying the signature value.
+	HeaderSignature = "Signature"
+)
+
+var (
+	// ErrMissingSignature is returned by Authenticate Strategy method,
+	// when the request missing the Signature-Input or Signature headers.
+	ErrMissingSignature = errors.New("strategies/httpsig: Request missing signature")
+
+	// ErrInvalidSignatureInput is returned by Authenticate Strategy method,
+	// when the request has a malformed Signature-Input or Signature headers,
+	// or the signature covers unsupported or missing components.
+	ErrInvalidSignatureInput = errors.New("strategies/httpsig: Invalid signature input")
+
+	// ErrInvalidSignature is returned by Authenticate Strategy method,
+	// when the request signature verification fails.
+	ErrInvalidSignature = errors.New("strategies/httpsig: Invalid signature")
+
+	// ErrSignatureExpired is returned by Authenticate Strategy method,
+	// when the signature expired, created in the future, or older than the allowed max age.
+	ErrSignatureExpired = errors.New("strategies/httpsig: Signature expired")
+
+	// ErrUnsupportedAlgorithm is returned by Authenticate Strategy method,
+	// when the signature algorithm not supported or does not match the resolved key type.
+	ErrUnsupportedAlgorithm = errors.New("strategies/httpsig: Unsupported algorithm")
+)
+
+// KeyResolver resolve a key id to its verification key and owner info.
+// The key must be one of *ecdsa.PublicKey (P-256), ed25519.PublicKey,
+// or []byte as HMAC-SHA256 shared secret.
+type KeyResolver interface {
+	Resolve(ctx context.Context, keyID string) (key interface{}, info auth.Info, err error)
+}
+
+// KeyResolverFunc is an adapter to allow the use of ordinary functions as KeyResolver.
+type KeyResolverFunc func(ctx context.Context, keyID string) (interface{}, auth.Info, error)
+
+// Resolve calls fn(ctx, keyID).
+func (fn KeyResolverFunc) Resolve(ctx context.Context, keyID string) (interface{}, auth.Info, error) {
+	return fn(ctx, keyID)
+}
+
+type strategy struct {
+	resolver KeyResolver
+	label    string
+	required []string
+	maxAge   time.Duration
+	skew     time.Duration
+	now      func() time.Time
+}
+
+func (s *strategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	input, sig, err := s.signature(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range s.required {
+		if !input.covers(c) {
+			return nil, ErrInvalidSignatureInput
+		}
+	}
+
+	keyID, ok := input.param("keyid").(string)
+	if !ok {
+		return nil, ErrInvalidSignatureInput
+	}
+
+	if err := s.verifyTime(input); err != nil {
+		return nil, err
+	}
+
+	base, err := signatureBase(r, input)
+	if err != nil {
+		return nil, err
+	}
+
+	key, info, err := s.resolver.Resolve(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	alg, _ := input.param("alg").(string)
+	if err := verify(alg, key, []byte(base), sig); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// signature return the covered components and signature value,
+// of the configured label or the first label present in both headers.
+func (s *strategy) signature(r *http.Request) (signatureInput, []byte, error) {
+	ih, sh := r.Header.Get(HeaderSignatureInput), r.Header.Get(HeaderSignature)
+	if len(ih) == 0 || len(sh) == 0 {
+		return signatureInput{}, nil, ErrMissingSignature
+	}
+
+	inputs, err := parseDictionary(ih)
+	if err != nil {
+		return signatureInput{}, nil, ErrInvalidSignatureInput
+	}
+
+	sigs, err := parseDictionary(sh)
+	if err != nil {
+		return signatureInput{}, nil, ErrInvalidSignatureInput
+	}
+
+	for _, m := range inputs {
+		if len(s.label) > 0 && m.key != s.label {
+			continue
+		}
+
+		v, ok := sigs.get(m.key)
+		if !ok {
+			continue
+		}
+
+		l, ok := m.value.(innerList)
+		if !ok {
+			return signatureInput{}, nil, ErrInvalidSignatureInput
+		}
+
+		it, ok := v.(item)
+		if !ok {
+			return signatureInput{}, nil, ErrInvalidSignatureInput
+		}
+
+		sig, ok := it.value.([]byte)
+		if !ok {
+			return signatureInput{}, nil, ErrInvalidSignatureInput
+		}
+
+		return signatureInput(l), sig, nil
+	}
+
+	return signatureInput{}, nil, ErrMissingSignature
+}
+
+func (s *strategy) verifyTime(input signatureInput) error {
+	now := s.now()
+
+	if v, ok := input.param("expires").(int64); ok && now.After(time.Unix(v, 0).Add(s.skew)) {
+		return ErrSignatureExpired
+	}
+
+	created, ok := input.param("created").(int64)
+	if !ok {
+		if s.maxAge > 0 {
+			return ErrSignatureExpired
+		}
+		return nil
+	}
+
+	t := time.Unix(created, 0)
+	if t.After(now.Add(s.skew)) || (s.maxAge > 0 && now.Sub(t) > s.maxAge+s.skew) {
+		return ErrSignatureExpired
+	}
+
+	return nil
+}
+
+// New return strategy authenticate request signed as defined in RFC 9421,
+// the signature key id resolved to its verification key using the provided resolver.
+// By default signatures created more than 5 minutes ago, or without created parameter rejected,
+// use SetMaxAge to override it.
+// By default signatures must cover the "@method", "@authority" and "@path" components,
+// use SetRequiredComponents to override it.
+func New(resolver KeyResolver, opts ...auth.Option) auth.Strategy {
+	s := new(strategy)
+	s.resolver = resolver
+	s.required = []string{"@method", "@authority", "@path"}
+	s.maxAge = time.Minute * 5
+	s.skew = time.Minute
+	s.now = time.Now
+
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	return s
+}
@@ -0,0 +1,358 @@
+package httpsig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// Test keys and vectors from RFC 9421 Appendix B.
+
+const testKeyECCP256 = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEqIVYZVLCrPZHGHjP17CTW0/+D9Lf
+w0EkjqF7xB4FivAxzic30tMM4GF+hR6Dxh71Z50VGGdldkkDXZCnTNnoXQ==
+-----END PUBLIC KEY-----
+`
+
+const testKeyEd25519 = `-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAJrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs=
+-----END PUBLIC KEY-----
+`
+
+const testSharedSecret = "uzvJfB4u3N0Jy4T7NZ75MDVcr8zSTInedJtkgcu46YW4XByzNJjxBdtjUkdJPBtbmHhIDi6pcl8jsasjlTMtDQ=="
+
+var (
+	errUnknownKey = errors.New("unknown key")
+	created       = time.Unix(1618884473, 0)
+)
+
+func testKeys(t *testing.T) KeyResolver {
+	secret, err := base64.StdEncoding.DecodeString(testSharedSecret)
+	assert.NoError(t, err)
+
+	keys := map[string]interface{}{
+		"test-key-ecc-p256":  parsePublicKey(t, testKeyECCP256),
+		"test-key-ed25519":   parsePublicKey(t, testKeyEd25519),
+		"test-shared-secret": secret,
+	}
+
+	return KeyResolverFunc(func(_ context.Context, keyID string) (interface{}, auth.Info, error) {
+		key, ok := keys[keyID]
+		if !ok {
+			return nil, nil, errUnknownKey
+		}
+		return key, auth.NewDefaultUser(keyID, "1", nil, nil), nil
+	})
+}
+
+func parsePublicKey(t *testing.T, s string) interface{} {
+	b, _ := pem.Decode([]byte(s))
+	key, err := x509.ParsePKIXPublicKey(b.Bytes)
+	assert.NoError(t, err)
+	return key
+}
+
+// testRequest return the RFC 9421 Appendix B.2 test request.
+func testRequest() *http.Request {
+	r := httptest.NewRequest("POST", "/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	r.Host = "example.com"
+	r.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:")
+	r.Header.Set("Content-Length", "18")
+	return r
+}
+
+func newStrategy(t *testing.T, now time.Time, opts ...auth.Option) auth.Strategy {
+	// RFC 9421 B.2.5 signature does not cover the default required components.
+	opts = append([]auth.Option{SetRequiredComponents()}, opts...)
+	s := New(testKeys(t), opts...)
+	s.(*strategy).now = func() time.Time { return now }
+	return s
+}
+
+func TestStrategy(t *testing.T) {
+	table := []struct {
+		name     string
+		input    string
+		sig      string
+		now      time.Time
+		opts     []auth.Option
+		modifier func(r *http.Request)
+		err      error
+		user     string
+	}{
+		{
+			name:  "it authenticate request signed with ed25519 (B.2.6)",
+			input: `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`,
+			sig:   `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`,
+			now:   created,
+			user:  "test-key-ed25519",
+		},
+		{
+			name:  "it authenticate request signed with hmac-sha256 (B.2.5)",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created.Add(time.Minute),
+			user:  "test-shared-secret",
+		},
+		{
+			name:  "it select signature by label",
+			input: `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519", sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:, sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			opts:  []auth.Option{SetLabel("sig-b25")},
+			user:  "test-shared-secret",
+		},
+		{
+			name:  "it return error when signed header modified",
+			input: `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`,
+			sig:   `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`,
+			now:   created,
+			modifier: func(r *http.Request) {
+				r.Header.Set("Content-Length", "19")
+			},
+			err: ErrInvalidSignature,
+		},
+		{
+			name:  "it return error when signature parameters modified",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884474;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrInvalidSignature,
+		},
+		{
+			name:  "it return error when signature older than max age",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created.Add(time.Minute * 10),
+			err:   ErrSignatureExpired,
+		},
+		{
+			name:  "it return error when signature created in the future",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created.Add(-time.Minute * 2),
+			err:   ErrSignatureExpired,
+		},
+		{
+			name:  "it return error when signature expired",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;expires=1618884483;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created.Add(time.Minute * 2),
+			err:   ErrSignatureExpired,
+		},
+		{
+			name:  "it return error when required component not covered",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			opts:  []auth.Option{SetRequiredComponents("@method", "@path")},
+			err:   ErrInvalidSignatureInput,
+		},
+		{
+			name:  "it return error when alg does not match key",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret";alg="ed25519"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrUnsupportedAlgorithm,
+		},
+		{
+			name:  "it return error when key id unknown",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="unknown"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   errUnknownKey,
+		},
+		{
+			name:  "it return error when key id missing",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrInvalidSignatureInput,
+		},
+		{
+			name:  "it return error when covered header missing",
+			input: `sig-b25=("date" "@authority" "x-missing");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrInvalidSignatureInput,
+		},
+		{
+			name:  "it return error when signature input malformed",
+			input: `sig-b25=("date" "@authority";created=1618884473`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrInvalidSignatureInput,
+		},
+		{
+			name:  "it return error when signature label not found",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig1=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			now:   created,
+			err:   ErrMissingSignature,
+		},
+		{
+			name: "it return error when request not signed",
+			now:  created,
+			err:  ErrMissingSignature,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStrategy(t, tt.now, tt.opts...)
+			r := testRequest()
+			if len(tt.input) > 0 {
+				r.Header.Set(HeaderSignatureInput, tt.input)
+				r.Header.Set(HeaderSignature, tt.sig)
+			}
+			if tt.modifier != nil {
+				tt.modifier(r)
+			}
+
+			info, err := s.Authenticate(r.Context(), r)
+
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.user, info.GetUserName())
+			}
+		})
+	}
+}
+
+func TestStrategyDefaultRequiredComponents(t *testing.T) {
+	created := time.Unix(1618884473, 0)
+
+	table := []struct {
+		name  string
+		input string
+		sig   string
+		err   error
+	}{
+		{
+			name:  "it authenticate request signature covers method, authority and path",
+			input: `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`,
+			sig:   `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`,
+		},
+		{
+			name:  "it return error when signature does not cover method and path",
+			input: `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			err:   ErrInvalidSignatureInput,
+		},
+		{
+			name:  "it return error when signature covers nothing",
+			input: `sig1=();created=1618884473;keyid="test-shared-secret"`,
+			sig:   `sig1=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`,
+			err:   ErrInvalidSignatureInput,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(testKeys(t))
+			s.(*strategy).now = func() time.Time { return created }
+
+			r := testRequest()
+			r.Header.Set(HeaderSignatureInput, tt.input)
+			r.Header.Set(HeaderSignature, tt.sig)
+
+			_, err := s.Authenticate(r.Context(), r)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestVerifyECDSAP256(t *testing.T) {
+	// RFC 9421 Appendix B.2.4 signs a response, hence verified against its signature base.
+	base := `"@status": 200
+"content-type": application/json
+"content-digest": sha-512=:mEWXIS7MaLRuGgxOBdODa3xqM1XdEvxoYhvlCFJ41QJgJc4GTsPp29l5oGX69wWdXymyU0rjJuahq4l5aGgfLQ==:
+"content-length": 23
+"@signature-params": ("@status" "content-type" "content-digest" "content-length");created=1618884473;keyid="test-key-ecc-p256"`
+	sig, _ := base64.StdEncoding.DecodeString("wNmSUAhwb5LxtOtOpNa6W5xj067m5hFrj0XQ4fvpaCLx0NKocgPquLgyahnzDnDAUy5eCdlYUEkLIj+32oiasw==")
+	key := parsePublicKey(t, testKeyECCP256)
+
+	assert.NoError(t, verify(ECDSAP256SHA256, key, []byte(base), sig))
+	assert.NoError(t, verify("", key, []byte(base), sig))
+
+	sig[0] ^= 1
+	assert.Equal(t, ErrInvalidSignature, verify(ECDSAP256SHA256, key, []byte(base), sig))
+}
+
+func TestSignatureBase(t *testing.T) {
+	l, err := parseDictionary(`sig1=("@method" "@authority" "@scheme" "@target-uri" "@request-target" "@path" "@query" "host");created=1618884473;keyid="test-key-rsa"`)
+	assert.NoError(t, err)
+
+	v, _ := l.get("sig1")
+	r := testRequest()
+	r.URL.Scheme = "https"
+
+	base, err := signatureBase(r, signatureInput(v.(innerList)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"@method": POST
+"@authority": example.com
+"@scheme": https
+"@target-uri": https://example.com/foo?param=Value&Pet=dog
+"@request-target": /foo?param=Value&Pet=dog
+"@path": /foo
+"@query": ?param=Value&Pet=dog
+"host": example.com
+"@signature-params": ("@method" "@authority" "@scheme" "@target-uri" "@request-target" "@path" "@query" "host");created=1618884473;keyid="test-key-rsa"`, base)
+}
+
+func TestSign(t *testing.T) {
+	ecc, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	edpub, edpriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	table := []struct {
+		name    string
+		private interface{}
+		public  interface{}
+	}{
+		{name: ECDSAP256SHA256, private: ecc, public: &ecc.PublicKey},
+		{name: Ed25519, private: edpriv, public: edpub},
+		{name: HMACSHA256, private: []byte("secret"), public: []byte("secret")},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := KeyResolverFunc(func(_ context.Context, keyID string) (interface{}, auth.Info, error) {
+				return tt.public, auth.NewDefaultUser(keyID, "1", nil, nil), nil
+			})
+			s := New(resolver, SetRequiredComponents("@method", "@authority", "@path"))
+
+			r := testRequest()
+			err := Sign(r, "sig1", "client", tt.private, "@method", "@authority", "@path", "content-digest")
+			assert.NoError(t, err)
+			assert.Contains(t, r.Header.Get(HeaderSignatureInput), `alg="`+tt.name+`"`)
+
+			info, err := s.Authenticate(r.Context(), r)
+			assert.NoError(t, err)
+			assert.Equal(t, "client", info.GetUserName())
+
+			r.Method = "PUT"
+			_, err = s.Authenticate(r.Context(), r)
+			assert.Equal(t, ErrInvalidSignature, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package httpsig
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetLabel sets the signature label to verify, when the request carries multiple signatures.
+// Default the first label present in both Signature-Input and Signature headers.
+func SetLabel(label string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.label = label
+		}
+	})
+}
+
+// SetRequiredComponents sets the components that must be covered by the signature,
+// to prevent replaying the signature against other endpoints or requests,
+// e.g "content-digest" to bind the signature to the request body.
+// Default Value "@method", "@authority", "@path".
+func SetRequiredComponents(components ...string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.required = components
+		}
+	})
+}
+
+// SetMaxAge sets the maximum allowed age of the signature created parameter,
+// to protect against replayed requests. Zero disables the check,
+// and allows signatures without created parameter.
+// Default Value 5 Minutes.
+func SetMaxAge(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.maxAge = d
+		}
+	})
+}
+
+// SetMaxSkew sets the maximum allowed clock difference between the client and the server,
+// applied when verifying the created and expires parameters.
+// Default Value 1 Minute.
+func SetMaxSkew(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*strategy); ok {
+			v.skew = d
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package httpsig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetLabel(t *testing.T) {
+	s := new(strategy)
+	opt := SetLabel("sig1")
+	opt.Apply(s)
+	assert.Equal(t, "sig1", s.label)
+}
+
+func TestSetRequiredComponents(t *testing.T) {
+	s := new(strategy)
+	opt := SetRequiredComponents("@method", "@path")
+	opt.Apply(s)
+	assert.Equal(t, []string{"@method", "@path"}, s.required)
+}
+
+func TestSetMaxAge(t *testing.T) {
+	s := new(strategy)
+	opt := SetMaxAge(time.Minute)
+	opt.Apply(s)
+	assert.Equal(t, time.Minute, s.maxAge)
+}
+
+func TestSetMaxSkew(t *testing.T) {
+	s := new(strategy)
+	opt := SetMaxSkew(time.Second)
+	opt.Apply(s)
+	assert.Equal(t, time.Second, s.skew)
+}
@@ -0,0 +1,407 @@
+package httpsig
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// This file implements the subset of RFC 8941 structured fields,
+// used by the Signature-Input and Signature headers.
+
+var errStructuredField = errors.New("invalid structured field")
+
+// token represents a structured field token, to differentiate it from string.
+type token string
+
+type param struct {
+	key   string
+	value interface{}
+}
+
+type params []param
+
+func (ps params) get(key string) (interface{}, bool) {
+	for _, p := range ps {
+		if p.key == key {
+			return p.value, true
+		}
+	}
+	return nil, false
+}
+
+type item struct {
+	value  interface{}
+	params params
+}
+
+type innerList struct {
+	items  []item
+	params params
+}
+
+type member struct {
+	key   string
+	value interface{} // item or innerList
+}
+
+type dictionary []member
+
+func (d dictionary) get(key string) (interface{}, bool) {
+	for _, m := range d {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+// parseDictionary parse a structured field dictionary, as defined in RFC 8941 section 4.2.2.
+func parseDictionary(s string) (dictionary, error) {
+	p := &sfParser{s: s}
+	d := dictionary{}
+	p.skip(" \t")
+
+	for !p.eof() {
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+		if p.consume('=') {
+			v, err = p.member()
+		} else {
+			var ps params
+			ps, err = p.params()
+			v = item{value: true, params: ps}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// duplicate keys overrides the previous value.
+		dup := false
+		for i, m := range d {
+			if m.key == key {
+				d[i].value = v
+				dup = true
+			}
+		}
+
+		if !dup {
+			d = append(d, member{key: key, value: v})
+		}
+
+		p.skip(" \t")
+		if p.eof() {
+			break
+		}
+
+		if !p.consume(',') {
+			return nil, errStructuredField
+		}
+
+		p.skip(" \t")
+		if p.eof() {
+			return nil, errStructuredField
+		}
+	}
+
+	return d, nil
+}
+
+type sfParser struct {
+	s string
+	i int
+}
+
+func (p *sfParser) eof() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *sfParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *sfParser) consume(c byte) bool {
+	if p.peek() == c && !p.eof() {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *sfParser) skip(chars string) {
+	for !p.eof() && strings.IndexByte(chars, p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *sfParser) member() (interface{}, error) {
+	if p.peek() == '(' {
+		return p.innerList()
+	}
+	return p.item()
+}
+
+func (p *sfParser) innerList() (innerList, error) {
+	l := innerList{}
+
+	if !p.consume('(') {
+		return l, errStructuredField
+	}
+
+	for !p.eof() {
+		p.skip(" ")
+
+		if p.consume(')') {
+			ps, err := p.params()
+			l.params = ps
+			return l, err
+		}
+
+		it, err := p.item()
+		if err != nil {
+			return l, err
+		}
+
+		l.items = append(l.items, it)
+
+		if c := p.peek(); c != ' ' && c != ')' {
+			return l, errStructuredField
+		}
+	}
+
+	return l, errStructuredField
+}
+
+func (p *sfParser) item() (item, error) {
+	v, err := p.bareItem()
+	if err != nil {
+		return item{}, err
+	}
+
+	ps, err := p.params()
+	return item{value: v, params: ps}, err
+}
+
+func (p *sfParser) params() (params, error) {
+	ps := params{}
+
+	for p.consume(';') {
+		p.skip(" ")
+
+		key, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{} = true
+		if p.consume('=') {
+			if v, err = p.bareItem(); err != nil {
+				return nil, err
+			}
+		}
+
+		ps = append(ps, param{key: key, value: v})
+	}
+
+	return ps, nil
+}
+
+func (p *sfParser) key() (string, error) {
+	start := p.i
+	c := p.peek()
+
+	if !isLCAlpha(c) && c != '*' {
+		return "", errStructuredField
+	}
+
+	for !p.eof() {
+		c := p.s[p.i]
+		if !isLCAlpha(c) && !isDigit(c) && strings.IndexByte("_-.*", c) < 0 {
+			break
+		}
+		p.i++
+	}
+
+	return p.s[start:p.i], nil
+}
+
+func (p *sfParser) bareItem() (interface{}, error) {
+	c := p.peek()
+
+	switch {
+	case c == '-' || isDigit(c):
+		return p.integer()
+	case c == '"':
+		return p.string()
+	case c == ':':
+		return p.byteSequence()
+	case c == '?':
+		return p.boolean()
+	case c == '*' || isAlpha(c):
+		return p.token(), nil
+	}
+
+	return nil, errStructuredField
+}
+
+func (p *sfParser) integer() (int64, error) {
+	start := p.i
+	p.consume('-')
+
+	for !p.eof() && isDigit(p.s[p.i]) {
+		p.i++
+	}
+
+	// decimals not used by signature parameters.
+	if p.peek() == '.' || p.i-start > 16 {
+		return 0, errStructuredField
+	}
+
+	return strconv.ParseInt(p.s[start:p.i], 10, 64)
+}
+
+func (p *sfParser) string() (string, error) {
+	b := new(strings.Builder)
+	p.i++
+
+	for !p.eof() {
+		c := p.s[p.i]
+		p.i++
+
+		switch {
+		case c == '\\':
+			if n := p.peek(); n == '"' || n == '\\' {
+				b.WriteByte(n)
+				p.i++
+				continue
+			}
+			return "", errStructuredField
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return "", errStructuredField
+		}
+
+		b.WriteByte(c)
+	}
+
+	return "", errStructuredField
+}
+
+func (p *sfParser) token() token {
+	start := p.i
+
+	for !p.eof() {
+		c := p.s[p.i]
+		if !isAlpha(c) && !isDigit(c) && strings.IndexByte(":/!#$%&'*+-.^_`|~", c) < 0 {
+			break
+		}
+		p.i++
+	}
+
+	return token(p.s[start:p.i])
+}
+
+func (p *sfParser) byteSequence() ([]byte, error) {
+	p.i++
+
+	end := strings.IndexByte(p.s[p.i:], ':')
+	if end < 0 {
+		return nil, errStructuredField
+	}
+
+	b, err := base64.StdEncoding.DecodeString(p.s[p.i : p.i+end])
+	p.i += end + 1
+
+	return b, err
+}
+
+func (p *sfParser) boolean() (bool, error) {
+	p.i++
+
+	switch {
+	case p.consume('1'):
+		return true, nil
+	case p.consume('0'):
+		return false, nil
+	}
+
+	return false, errStructuredField
+}
+
+// serialize the inner list as defined in RFC 8941 section 4.1.1.1.
+func (l innerList) serialize() string {
+	b := new(strings.Builder)
+	b.WriteByte('(')
+
+	for i, it := range l.items {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(serializeBareItem(it.value))
+		b.WriteString(it.params.serialize())
+	}
+
+	b.WriteByte(')')
+	b.WriteString(l.params.serialize())
+
+	return b.String()
+}
+
+func (ps params) serialize() string {
+	b := new(strings.Builder)
+
+	for _, p := range ps {
+		b.WriteByte(';')
+		b.WriteString(p.key)
+		if v, ok := p.value.(bool); ok && v {
+			continue
+		}
+		b.WriteByte('=')
+		b.WriteString(serializeBareItem(p.value))
+	}
+
+	return b.String()
+}
+
+func serializeBareItem(v interface{}) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case string:
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		return `"` + r.Replace(v) + `"`
+	case token:
+		return string(v)
+	case []byte:
+		return ":" + base64.StdEncoding.EncodeToString(v) + ":"
+	case bool:
+		if v {
+			return "?1"
+		}
+		return "?0"
+	}
+
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLCAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isAlpha(c byte) bool {
+	return isLCAlpha(c) || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,69 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+var (
+	// ErrRevokedToken is returned by Authenticate Strategy method,
+	// when token jti claim has been revoked.
+	ErrRevokedToken = errors.New("strategies/jwt: Token has been revoked")
+
+	// ErrMissingJTI is returned by Authenticate Strategy method,
+	// when token missing jti claim and blacklist reject such tokens.
+	ErrMissingJTI = errors.New("strategies/jwt: Token missing jti claim")
+)
+
+// Blacklist holds revoked tokens ids (jti claim),
+// to invalidate stateless jwt tokens before they expire.
+//
+// Blacklist only consulted when a token parsed,
+// the cached authentication decision of a revoked token must be revoked from the strategy cache,
+// using auth.Revoke function, Otherwise, it remains valid until the decision evicted from the cache.
+type Blacklist struct {
+	// RejectMissingJTI reject tokens without jti claim,
+	// since they can not be revoked.
+	// Default false.
+	RejectMissingJTI bool
+
+	cache auth.Cache
+}
+
+// NewBlacklist return's new Blacklist,
+// that store revoked tokens ids in c.
+func NewBlacklist(c auth.Cache) *Blacklist {
+	return &Blacklist{cache: c}
+}
+
+// Revoke token id until the given time,
+// typically the token expiry time, since afterwards it's rejected anyway.
+// Revoke is a no-op when until already passed.
+func (b *Blacklist) Revoke(jti string, until time.Time) {
+	// the cache treats a non-positive ttl as no expiry.
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return
+	}
+	b.cache.StoreWithTTL(jti, struct{}{}, ttl)
+}
+
+// IsRevoked reports whether the token id revoked.
+func (b *Blacklist) IsRevoked(jti string) bool {
+	_, ok := b.cache.Load(jti)
+	return ok
+}
+
+func (b *Blacklist) verify(jti string) error {
+	switch {
+	case len(jti) == 0 && b.RejectMissingJTI:
+		return ErrMissingJTI
+	case len(jti) == 0:
+		return nil
+	case b.IsRevoked(jti):
+		return ErrRevokedToken
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal/jwt"
+)
+
+func TestBlacklist(t *testing.T) {
+	s := StaticSecret{ID: "kid", Secret: []byte("test-secret"), Algorithm: HS256}
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+
+	t.Run("it reject token revoked before expiry", func(t *testing.T) {
+		b := NewBlacklist(libcache.LRU.New(0))
+		tk := newAccessToken(s, SetBlacklist(b))
+		str, err := tk.issue(info)
+		assert.NoError(t, err)
+
+		c, _, err := tk.parse(str)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, c.JWTID)
+
+		b.Revoke(c.JWTID, time.Time(*c.ExpiresAt))
+		_, _, err = tk.parse(str)
+		assert.Equal(t, ErrRevokedToken, err)
+	})
+
+	t.Run("it accept token once revocation expired", func(t *testing.T) {
+		b := NewBlacklist(libcache.LRU.New(0))
+		tk := newAccessToken(s, SetBlacklist(b))
+		str, err := tk.issue(info)
+		assert.NoError(t, err)
+
+		c, _, _ := tk.parse(str)
+		b.Revoke(c.JWTID, time.Now().Add(time.Millisecond*10))
+		assert.True(t, b.IsRevoked(c.JWTID))
+
+		time.Sleep(time.Millisecond * 20)
+		assert.False(t, b.IsRevoked(c.JWTID))
+		_, _, err = tk.parse(str)
+		assert.NoError(t, err)
+	})
+
+	t.Run("it does not store revocation already expired", func(t *testing.T) {
+		cache := libcache.LRU.New(0)
+		b := NewBlacklist(cache)
+		b.Revoke("jti", time.Now().Add(-time.Second))
+		assert.False(t, b.IsRevoked("jti"))
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("it apply missing jti policy", func(t *testing.T) {
+		exp := time.Now().Add(time.Hour)
+		str, err := jwt.IssueToken(s, claimsWithoutJTI{ExpiresAt: exp.Unix(), Audience: []string{""}})
+		assert.NoError(t, err)
+
+		b := NewBlacklist(libcache.LRU.New(0))
+		tk := newAccessToken(s, SetBlacklist(b))
+		_, _, err = tk.parse(str)
+		assert.NoError(t, err)
+
+		b.RejectMissingJTI = true
+		_, _, err = tk.parse(str)
+		assert.Equal(t, ErrMissingJTI, err)
+	})
+}
+
+type claimsWithoutJTI struct {
+	ExpiresAt int64    `json:"exp"`
+	Audience  []string `json:"aud"`
+}
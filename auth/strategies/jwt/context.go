@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+type claimsKey struct{}
+
+// MapClaims represents all jwt token claims as a map,
+// including the standard and custom claims.
+type MapClaims map[string]interface{}
+
+// ClaimsFromContext return jwt token claims stored in context by InjectClaims.
+func ClaimsFromContext(ctx context.Context) (MapClaims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(MapClaims)
+	return c, ok
+}
+
+// InjectClaims return http.Handler that parse and verify request jwt token using s,
+// and store the token claims into the request context before invoking next,
+// so downstream handlers can read them using ClaimsFromContext without re-parsing the token.
+//
+// InjectClaims does not reject requests, a request without a valid token
+// passed to next with an untouched context.
+func InjectClaims(s SecretsKeeper, next http.Handler, opts ...auth.Option) http.Handler {
+	t := newAccessToken(s, opts...)
+	p := token.AuthorizationParser(string(token.Bearer))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tk, err := p.Token(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		c := make(MapClaims)
+		if _, _, err := t.parse(tk, &c); err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), claimsKey{}, c)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,65 @@
+package jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestInjectClaims(t *testing.T) {
+	s := StaticSecret{ID: "kid", Secret: []byte("test-secret"), Algorithm: HS256}
+	info := auth.NewDefaultUser("test", "test-id", nil, nil)
+	tk, err := IssueAccessToken(info, s, SetIssuer("test-iss"))
+	assert.NoError(t, err)
+
+	table := []struct {
+		name     string
+		token    string
+		expected bool
+	}{
+		{
+			name:     "it inject claims when token valid",
+			token:    tk,
+			expected: true,
+		},
+		{
+			name:  "it leave context untouched when token missing",
+			token: "",
+		},
+		{
+			name:  "it leave context untouched when token invalid",
+			token: tamperPayload(tk),
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				c  MapClaims
+				ok bool
+			)
+
+			h := InjectClaims(s, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c, ok = ClaimsFromContext(r.Context())
+			}))
+
+			r, _ := http.NewRequest("GET", "/", nil)
+			if len(tt.token) > 0 {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.expected, ok)
+			if tt.expected {
+				assert.Equal(t, "test-id", c["sub"])
+				assert.Equal(t, "test-iss", c["iss"])
+				assert.Equal(t, "test", c["Name"])
+			}
+		})
+	}
+}
@@ -154,3 +154,32 @@ func ExampleSetNamedScopes() {
 	_, _ = jwt.IssueAccessToken(u, s, ns)
 	_ = jwt.New(c, s, opt)
 }
+
+func ExampleKeySet() {
+	u := auth.NewUserInfo("example", "example", nil, nil)
+	c := libcache.LRU.New(0)
+	k := jwt.NewKeySet(jwt.StaticSecret{
+		ID:        "old",
+		Algorithm: jwt.HS256,
+		Secret:    []byte("your old secret"),
+	})
+
+	token, _ := jwt.IssueAccessToken(u, k)
+	strategy := jwt.New(c, k)
+
+	// rotate the signing secret, tokens issued using old secret remain valid.
+	k.Add(jwt.StaticSecret{
+		ID:        "new",
+		Algorithm: jwt.HS256,
+		Secret:    []byte("your new secret"),
+	})
+
+	// user request
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	user, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(user.GetID(), err, k.KID())
+
+	// Output:
+	// example <nil> new
+}
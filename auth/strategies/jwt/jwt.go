@@ -4,10 +4,13 @@ package jwt
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
 	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
 )
 
@@ -25,10 +28,49 @@ func GetAuthenticateFunc(s SecretsKeeper, opts ...auth.Option) token.Authenticat
 			token.WithNamedScopes(info, c.Scope.Split()...)
 		}
 
+		if err := withClaims(info, c); err != nil {
+			return nil, time.Time{}, err
+		}
+
 		return info, time.Time(*c.ExpiresAt), nil
 	}
 }
 
+const claimsExtName = "x-go-guardian-jwt-claims"
+
+// withClaims store the verified token standard claims into info extensions.
+func withClaims(info auth.Info, c claims.Standard) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("strategies/jwt: %w", err)
+	}
+
+	ext := info.GetExtensions()
+	if ext == nil {
+		ext = auth.Extensions{}
+	}
+
+	ext.Set(claimsExtName, string(b))
+	info.SetExtensions(ext)
+	return nil
+}
+
+// GetClaims return the verified token standard claims,
+// of info authenticated by the jwt strategy, Otherwise false.
+func GetClaims(info auth.Info) (claims.Standard, bool) {
+	c := claims.Standard{}
+	ext := info.GetExtensions()
+	if ext == nil || !ext.Has(claimsExtName) {
+		return c, false
+	}
+
+	if err := json.Unmarshal([]byte(ext.Get(claimsExtName)), &c); err != nil {
+		return c, false
+	}
+
+	return c, true
+}
+
 // New return strategy authenticate request using jwt token.
 //
 // New is similar to:
@@ -0,0 +1,97 @@
+package jwt
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+)
+
+type ttlCache struct {
+	libcache.Cache
+	ttl map[interface{}]time.Duration
+}
+
+func (c ttlCache) StoreWithTTL(key, value interface{}, ttl time.Duration) {
+	c.ttl[key] = ttl
+	c.Cache.StoreWithTTL(key, value, ttl)
+}
+
+func TestCacheTTL(t *testing.T) {
+	keeper := StaticSecret{
+		ID:        "kid",
+		Secret:    []byte("test-secret"),
+		Algorithm: HS256,
+	}
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+
+	table := []struct {
+		name   string
+		exp    time.Duration
+		cached bool
+	}{
+		{
+			name:   "it cache token until its expiry",
+			exp:    time.Second * 30,
+			cached: true,
+		},
+		{
+			name: "it does not cache token past its expiry within leeway",
+			exp:  -time.Second * 30,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := ttlCache{Cache: libcache.LRU.New(0), ttl: make(map[interface{}]time.Duration)}
+			s := New(cache, keeper)
+
+			// issue subtract the leeway from the token times.
+			tk, err := IssueAccessToken(info, keeper, SetExpDuration(tt.exp+claims.DefaultLeeway))
+			assert.NoError(t, err)
+
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+tk)
+			_, err = s.Authenticate(r.Context(), r)
+			assert.NoError(t, err)
+
+			ttl, ok := cache.ttl[tk]
+			assert.Equal(t, tt.cached, ok)
+			if tt.cached {
+				assert.InDelta(t, tt.exp, ttl, float64(time.Second))
+			}
+		})
+	}
+}
+
+func TestGetClaims(t *testing.T) {
+	keeper := StaticSecret{
+		ID:        "kid",
+		Secret:    []byte("test-secret"),
+		Algorithm: HS256,
+	}
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+
+	_, ok := GetClaims(info)
+	assert.False(t, ok)
+
+	tk, err := IssueAccessToken(info, keeper, SetIssuer("test-iss"))
+	assert.NoError(t, err)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+tk)
+	got, err := New(libcache.LRU.New(0), keeper).Authenticate(r.Context(), r)
+	assert.NoError(t, err)
+
+	c, ok := GetClaims(got)
+	assert.True(t, ok)
+	assert.Equal(t, "1", c.Subject)
+	assert.Equal(t, "test-iss", c.Issuer)
+	assert.NotNil(t, c.ExpiresAt)
+}
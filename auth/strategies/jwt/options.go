@@ -44,3 +44,13 @@ func SetNamedScopes(scp ...string) auth.Option {
 		}
 	})
 }
+
+// SetBlacklist sets the blacklist to reject revoked tokens,
+// no default value.
+func SetBlacklist(b *Blacklist) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if t, ok := v.(*accessToken); ok {
+			t.blacklist = b
+		}
+	})
+}
@@ -25,3 +25,10 @@ func TestSetExpDuration(t *testing.T) {
 	tk := newAccessToken(nil, opt)
 	assert.Equal(t, time.Hour, tk.dur)
 }
+
+func TestSetBlacklist(t *testing.T) {
+	b := NewBlacklist(nil)
+	opt := SetBlacklist(b)
+	tk := newAccessToken(nil, opt)
+	assert.Equal(t, b, tk.blacklist)
+}
@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"errors"
+	"sync"
 )
 
 // SecretsKeeper hold all secrets/keys to sign and parse JWT token
@@ -35,3 +36,76 @@ func (s StaticSecret) Get(kid string) (key interface{}, algorithm string, err er
 
 	return s.Secret, s.Algorithm, nil
 }
+
+// KeySet implements the SecretsKeeper and holds multiple secrets,
+// to rotate the signing secret without invalidating the already issued tokens.
+// The first secret in the set is the primary secret and it used to issue new tokens,
+// while the other secrets remain valid to verify tokens until they removed from the set.
+//
+// KeySet is safe for concurrent use.
+type KeySet struct {
+	mu      sync.RWMutex
+	secrets []StaticSecret
+}
+
+// NewKeySet return's new KeySet holding the provided secrets,
+// where the first secret is the primary.
+func NewKeySet(secrets ...StaticSecret) *KeySet {
+	k := new(KeySet)
+	k.secrets = append(k.secrets, secrets...)
+	return k
+}
+
+// KID return's the primary secret id.
+func (k *KeySet) KID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if len(k.secrets) == 0 {
+		return ""
+	}
+
+	return k.secrets[0].ID
+}
+
+// Get return's secret and the corresponding sign algorithm.
+func (k *KeySet) Get(kid string) (key interface{}, algorithm string, err error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	for _, s := range k.secrets {
+		if s.ID == kid {
+			return s.Secret, s.Algorithm, nil
+		}
+	}
+
+	msg := "strategies/jwt: Invalid " + kid + " KID"
+	return nil, "", errors.New(msg)
+}
+
+// Add secret to the set as the primary secret,
+// previous secrets remain valid to verify tokens.
+// If the set already contains a secret with the same id, it replaced.
+func (k *KeySet) Add(s StaticSecret) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.secrets = append([]StaticSecret{s}, k.remove(s.ID)...)
+}
+
+// Remove secret from the set,
+// tokens signed by the removed secret no longer verified.
+func (k *KeySet) Remove(kid string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.secrets = k.remove(kid)
+}
+
+func (k *KeySet) remove(kid string) []StaticSecret {
+	secrets := make([]StaticSecret, 0, len(k.secrets))
+	for _, s := range k.secrets {
+		if s.ID != kid {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
@@ -3,6 +3,8 @@ package jwt
 import (
 	"testing"
 
+	"github.com/shaj13/go-guardian/v2/auth"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,3 +33,51 @@ func TestStaticSecretGet(t *testing.T) {
 		assert.Empty(t, alg)
 	})
 }
+
+func TestKeySet(t *testing.T) {
+	old := StaticSecret{ID: "old", Secret: []byte("old-secret"), Algorithm: HS256}
+	latest := StaticSecret{ID: "latest", Secret: []byte("latest-secret"), Algorithm: HS512}
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+
+	k := NewKeySet(old)
+	assert.Equal(t, "old", k.KID())
+
+	oldToken, err := newAccessToken(k).issue(info)
+	assert.NoError(t, err)
+
+	k.Add(latest)
+	assert.Equal(t, "latest", k.KID())
+
+	latestToken, err := newAccessToken(k).issue(info)
+	assert.NoError(t, err)
+
+	_, _, err = newAccessToken(k).parse(oldToken)
+	assert.NoError(t, err, "token signed with the old secret still valid after rotation")
+
+	_, _, err = newAccessToken(k).parse(latestToken)
+	assert.NoError(t, err)
+
+	k.Remove("old")
+	_, _, err = newAccessToken(k).parse(oldToken)
+	assert.Error(t, err)
+
+	k.Remove("latest")
+	assert.Empty(t, k.KID())
+}
+
+func TestKeySetAdd(t *testing.T) {
+	k := NewKeySet(
+		StaticSecret{ID: "1", Secret: []byte("1")},
+		StaticSecret{ID: "2", Secret: []byte("2")},
+	)
+
+	k.Add(StaticSecret{ID: "2", Secret: []byte("updated"), Algorithm: HS384})
+
+	assert.Equal(t, "2", k.KID())
+	assert.Len(t, k.secrets, 2)
+
+	secret, alg, err := k.Get("2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), secret)
+	assert.Equal(t, HS384, alg)
+}
@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"time"
 
@@ -10,7 +12,9 @@ import (
 )
 
 const (
-	// EdDSA signature algorithm.
+	// EdDSA signature algorithm -- Ed25519 as defined in RFC 8037.
+	// EdDSA issue tokens significantly faster than RSA,
+	// while HMAC remains the cheapest to issue and to verify, see BenchmarkToken.
 	EdDSA = "EdDSA"
 	// HS256 signature algorithm -- HMAC using SHA-256.
 	HS256 = "HS256"
@@ -54,17 +58,23 @@ func IssueAccessToken(info auth.Info, s SecretsKeeper, opts ...auth.Option) (str
 }
 
 type accessToken struct {
-	keeper SecretsKeeper
-	dur    time.Duration
-	aud    string
-	iss    string
-	scp    []string
+	keeper    SecretsKeeper
+	blacklist *Blacklist
+	dur       time.Duration
+	aud       string
+	iss       string
+	scp       []string
 }
 
 func (at accessToken) issue(info auth.Info) (string, error) {
 	now := time.Now().UTC().Add(-claims.DefaultLeeway)
 	exp := now.Add(at.dur)
 
+	jti, err := tokenID()
+	if err != nil {
+		return "", fmt.Errorf("strategies/jwt: %w", err)
+	}
+
 	c := claims.Standard{
 		Subject:   info.GetID(),
 		Issuer:    at.iss,
@@ -73,6 +83,7 @@ func (at accessToken) issue(info auth.Info) (string, error) {
 		IssuedAt:  (*claims.Time)(&now),
 		NotBefore: (*claims.Time)(&now),
 		Scope:     at.scp,
+		JWTID:     jti,
 	}
 
 	str, err := jwt.IssueToken(at.keeper, c, info)
@@ -83,7 +94,7 @@ func (at accessToken) issue(info auth.Info) (string, error) {
 	return str, nil
 }
 
-func (at accessToken) parse(tstr string) (claims.Standard, auth.Info, error) {
+func (at accessToken) parse(tstr string, dest ...interface{}) (claims.Standard, auth.Info, error) {
 	fail := func(err error) (claims.Standard, auth.Info, error) {
 		return claims.Standard{}, nil, fmt.Errorf("strategies/jwt: %w", err)
 	}
@@ -98,7 +109,9 @@ func (at accessToken) parse(tstr string) (claims.Standard, auth.Info, error) {
 		},
 	}
 
-	if err := jwt.ParseToken(at.keeper, tstr, &c, info); err != nil {
+	dest = append([]interface{}{&c, info}, dest...)
+
+	if err := jwt.ParseToken(at.keeper, tstr, dest...); err != nil {
 		return fail(err)
 	}
 
@@ -106,9 +119,23 @@ func (at accessToken) parse(tstr string) (claims.Standard, auth.Info, error) {
 		return fail(err)
 	}
 
+	if at.blacklist != nil {
+		if err := at.blacklist.verify(c.JWTID); err != nil {
+			return claims.Standard{}, nil, err
+		}
+	}
+
 	return c, info, nil
 }
 
+func tokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 func newAccessToken(s SecretsKeeper, opts ...auth.Option) *accessToken {
 	t := new(accessToken)
 	t.keeper = s
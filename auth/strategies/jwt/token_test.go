@@ -1,6 +1,13 @@
 package jwt
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"strings"
 	"testing"
 	"time"
 
@@ -72,6 +79,187 @@ func TestTokenAlg(t *testing.T) {
 	assert.Contains(t, err.Error(), ErrInvalidAlg.Error())
 }
 
+func TestTokenRSA(t *testing.T) {
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	table := []struct {
+		name        string
+		alg         string
+		verifier    SecretsKeeper
+		tamper      bool
+		expectedErr error
+	}{
+		{
+			name:     "it verify RS256 token using public key",
+			alg:      RS256,
+			verifier: StaticSecret{ID: "kid", Secret: &key.PublicKey, Algorithm: RS256},
+		},
+		{
+			name:     "it verify RS512 token using public key",
+			alg:      RS512,
+			verifier: StaticSecret{ID: "kid", Secret: &key.PublicKey, Algorithm: RS512},
+		},
+		{
+			name:     "it verify RS256 token using private key",
+			alg:      RS256,
+			verifier: StaticSecret{ID: "kid", Secret: key, Algorithm: RS256},
+		},
+		{
+			name:        "it reject token when alg header does not match key algorithm",
+			alg:         RS256,
+			verifier:    StaticSecret{ID: "kid", Secret: &key.PublicKey, Algorithm: RS512},
+			expectedErr: ErrInvalidAlg,
+		},
+		{
+			name:     "it reject token when payload tampered",
+			alg:      RS512,
+			verifier: StaticSecret{ID: "kid", Secret: &key.PublicKey, Algorithm: RS512},
+			tamper:   true,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := StaticSecret{ID: "kid", Secret: key, Algorithm: tt.alg}
+			str, err := newAccessToken(signer).issue(info)
+			assert.NoError(t, err)
+
+			if tt.tamper {
+				str = tamperPayload(str)
+			}
+
+			_, u, err := newAccessToken(tt.verifier).parse(str)
+
+			switch {
+			case tt.expectedErr != nil:
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+			case tt.tamper:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, info.GetID(), u.GetID())
+			}
+		})
+	}
+}
+
+func TestTokenECDSA(t *testing.T) {
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	assert.NoError(t, err)
+
+	table := []struct {
+		name        string
+		signer      SecretsKeeper
+		verifier    SecretsKeeper
+		expectedErr bool
+	}{
+		{
+			name:     "it verify ES256 token",
+			signer:   StaticSecret{ID: "kid", Secret: p256, Algorithm: ES256},
+			verifier: StaticSecret{ID: "kid", Secret: &p256.PublicKey, Algorithm: ES256},
+		},
+		{
+			name:     "it verify ES384 token",
+			signer:   StaticSecret{ID: "kid", Secret: p384, Algorithm: ES384},
+			verifier: StaticSecret{ID: "kid", Secret: &p384.PublicKey, Algorithm: ES384},
+		},
+		{
+			name:     "it verify ES512 token",
+			signer:   StaticSecret{ID: "kid", Secret: p521, Algorithm: ES512},
+			verifier: StaticSecret{ID: "kid", Secret: &p521.PublicKey, Algorithm: ES512},
+		},
+		{
+			name:        "it reject ES256 token when verifier alg is ES384",
+			signer:      StaticSecret{ID: "kid", Secret: p256, Algorithm: ES256},
+			verifier:    StaticSecret{ID: "kid", Secret: &p384.PublicKey, Algorithm: ES384},
+			expectedErr: true,
+		},
+		{
+			name:        "it reject ES256 token when verifier key curve is P-384",
+			signer:      StaticSecret{ID: "kid", Secret: p256, Algorithm: ES256},
+			verifier:    StaticSecret{ID: "kid", Secret: &p384.PublicKey, Algorithm: ES256},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			str, err := newAccessToken(tt.signer).issue(info)
+			assert.NoError(t, err)
+
+			_, u, err := newAccessToken(tt.verifier).parse(str)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, info.GetID(), u.GetID())
+		})
+	}
+}
+
+func TestTokenEdDSA(t *testing.T) {
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	table := []struct {
+		name        string
+		verifier    SecretsKeeper
+		tamper      bool
+		expectedErr bool
+	}{
+		{
+			name:     "it verify EdDSA token",
+			verifier: StaticSecret{ID: "kid", Secret: pub, Algorithm: EdDSA},
+		},
+		{
+			name:        "it reject EdDSA token when payload tampered",
+			verifier:    StaticSecret{ID: "kid", Secret: pub, Algorithm: EdDSA},
+			tamper:      true,
+			expectedErr: true,
+		},
+		{
+			name:        "it reject EdDSA token when verified using wrong key",
+			verifier:    StaticSecret{ID: "kid", Secret: otherPub, Algorithm: EdDSA},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := StaticSecret{ID: "kid", Secret: priv, Algorithm: EdDSA}
+			str, err := newAccessToken(signer).issue(info)
+			assert.NoError(t, err)
+
+			if tt.tamper {
+				str = tamperPayload(str)
+			}
+
+			_, u, err := newAccessToken(tt.verifier).parse(str)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, info.GetID(), u.GetID())
+		})
+	}
+}
+
 func TestTokenKID(t *testing.T) {
 	str := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e30.P4Lqll22jQQJ1eMJikvNg5HKG-cKB0hUZA9BZFIG7Jk"
 	tk := newAccessToken(nil)
@@ -88,6 +276,60 @@ func TestNewToken(t *testing.T) {
 	}
 }
 
+func BenchmarkToken(b *testing.B) {
+	info := auth.NewDefaultUser("test", "test", nil, nil)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	table := []struct {
+		name   string
+		keeper SecretsKeeper
+	}{
+		{
+			name:   HS256,
+			keeper: StaticSecret{ID: "kid", Secret: []byte("test-secret"), Algorithm: HS256},
+		},
+		{
+			name:   RS256,
+			keeper: StaticSecret{ID: "kid", Secret: rsaKey, Algorithm: RS256},
+		},
+		{
+			name:   EdDSA,
+			keeper: StaticSecret{ID: "kid", Secret: edKey, Algorithm: EdDSA},
+		},
+	}
+
+	for _, tt := range table {
+		tk := newAccessToken(tt.keeper)
+
+		b.Run(tt.name+"/Issue", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := tk.issue(info); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+
+		b.Run(tt.name+"/Parse", func(b *testing.B) {
+			str, _ := tk.issue(info)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := tk.parse(str); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+}
+
+// tamperPayload replace jwt token payload with a forged one,
+// while keeping the original header and signature.
+func tamperPayload(token string) string {
+	parts := strings.Split(token, ".")
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin"}`))
+	return parts[0] + "." + payload + "." + parts[2]
+}
+
 // testUser has been added to verify we still can marshal/unmarshal
 // customized auth.info from jwt claims.
 type testUser struct {
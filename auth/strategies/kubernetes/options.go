@@ -44,7 +44,7 @@ func SetAddress(addr string) auth.Option {
 // e.g authentication.k8s.io/v1
 func SetAPIVersion(version string) auth.Option {
 	version = strings.TrimPrefix(strings.TrimSuffix(version, "/"), "/")
-	return internal.SetRequesterAddress("/apis/" + version + "/tokenreviews")
+	return internal.SetRequesterEndpoint("/apis/" + version + "/tokenreviews")
 }
 
 // SetAudiences sets the list of the identifiers that the resource server presented
@@ -49,10 +49,11 @@ func TestSetAddress(t *testing.T) {
 }
 
 func TestSetAPIVersion(t *testing.T) {
-	ver := "authentication.k8s.io/v1"
+	ver := "/authentication.k8s.io/v1beta1/"
 	opt := SetAPIVersion(ver)
 	kr := newKubeReview(opt)
-	assert.Contains(t, kr.requester.Endpoint, ver)
+	assert.Equal(t, "/apis/authentication.k8s.io/v1beta1/tokenreviews", kr.requester.Endpoint)
+	assert.Equal(t, "http://127.0.0.1:6443", kr.requester.Addr)
 }
 
 func TestSetAudiences(t *testing.T) {
@@ -35,6 +35,9 @@ type Config struct {
 	Host string
 	// TLS configuration, if nil connect without TLS.
 	TLS *tls.Config
+	// StartTLS upgrade a plain LDAP connection to TLS using the TLS configuration,
+	// instead of connecting over LDAPS.
+	StartTLS bool
 	// BindDN represents LDAP DN for searching for the user DN.
 	// Typically read only user DN.
 	BindDN string
@@ -43,6 +46,10 @@ type Config struct {
 	BindPassword string
 	// Attributes used for users.
 	Attributes []string
+	// GroupsAttribute define the attribute that holds the user group membership.
+	// e.g memberOf, if empty groups are not populated.
+	// The attribute requested in addition to Attributes.
+	GroupsAttribute string
 	// BaseDN LDAP domain to use for users.
 	BaseDN string
 	// Filter for the User Object Filter.
@@ -55,7 +62,7 @@ func dial(cfg *Config) (conn, error) {
 	scheme := "ldap"
 	opts := []ldap.DialOpt{}
 
-	if cfg.TLS != nil {
+	if cfg.TLS != nil && !cfg.StartTLS {
 		scheme = "ldaps"
 		opts = append(opts, ldap.DialWithTLSConfig(cfg.TLS))
 	}
@@ -69,14 +76,20 @@ type client struct {
 	cfg  *Config
 }
 
-func (c client) authenticate(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) { //nolint:lll
+// bind dial the LDAP server and bind the search user.
+func (c client) bind() (conn, error) {
 	l, err := c.dial(c.cfg)
 
 	if err != nil {
 		return nil, err
 	}
 
-	defer l.Close()
+	if c.cfg.StartTLS {
+		if err := l.StartTLS(c.cfg.TLS); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
 
 	if c.cfg.BindPassword != "" {
 		err = l.Bind(c.cfg.BindDN, c.cfg.BindPassword)
@@ -84,15 +97,57 @@ func (c client) authenticate(ctx context.Context, r *http.Request, userName, pas
 		err = l.UnauthenticatedBind(c.cfg.BindDN)
 	}
 
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// healthCheck bind the search user, and return once bind done or ctx done.
+// go-ldap dialing and binding does not accept a context,
+// therefore on ctx done the connection closed once bind return.
+func (c client) healthCheck(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("strategies/ldap: %w", err)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		l, err := c.bind()
+		if err == nil {
+			l.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("strategies/ldap: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("strategies/ldap: %w", ctx.Err())
+	}
+}
+
+func (c client) authenticate(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) { //nolint:lll
+	l, err := c.bind()
+
 	if err != nil {
 		return nil, err
 	}
 
+	defer l.Close()
+
 	result, err := l.Search(&ldap.SearchRequest{
 		BaseDN:     c.cfg.BaseDN,
 		Scope:      ldap.ScopeWholeSubtree,
 		Filter:     fmt.Sprintf(c.cfg.Filter, userName),
-		Attributes: c.cfg.Attributes,
+		Attributes: c.attributes(),
 	})
 
 	if err != nil {
@@ -110,6 +165,7 @@ func (c client) authenticate(ctx context.Context, r *http.Request, userName, pas
 	}
 
 	id := ""
+	groups := []string{}
 	ext := map[string][]string{}
 
 	for _, attr := range result.Entries[0].Attributes {
@@ -121,10 +177,32 @@ func (c client) authenticate(ctx context.Context, r *http.Request, userName, pas
 			continue
 		}
 
+		if len(c.cfg.GroupsAttribute) > 0 && name == c.cfg.GroupsAttribute {
+			groups = values
+			continue
+		}
+
 		ext[name] = values
 	}
 
-	return auth.NewUserInfo(userName, id, nil, ext), nil
+	return auth.NewUserInfo(userName, id, groups, ext), nil
+}
+
+// attributes return the search attributes, including the groups attribute,
+// unless the attributes are empty which request all attributes.
+func (c client) attributes() []string {
+	attrs := c.cfg.Attributes
+	if len(attrs) == 0 || len(c.cfg.GroupsAttribute) == 0 {
+		return attrs
+	}
+
+	for _, attr := range attrs {
+		if attr == c.cfg.GroupsAttribute {
+			return attrs
+		}
+	}
+
+	return append(append([]string{}, attrs...), c.cfg.GroupsAttribute)
 }
 
 // GetAuthenticateFunc return function to authenticate request using LDAP.
@@ -136,6 +214,15 @@ func GetAuthenticateFunc(cfg *Config, opts ...auth.Option) basic.AuthenticateFun
 	return cl.authenticate
 }
 
+// GetHealthCheckFunc return function to check the LDAP server reachable,
+// and the search user able to bind.
+func GetHealthCheckFunc(cfg *Config) func(ctx context.Context) error {
+	cl := new(client)
+	cl.dial = dial
+	cl.cfg = cfg
+	return cl.healthCheck
+}
+
 // New return strategy authenticate request using LDAP.
 // New is similar to Basic.New().
 func New(cfg *Config, opts ...auth.Option) auth.Strategy {
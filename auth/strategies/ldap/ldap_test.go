@@ -3,11 +3,13 @@ package ldap
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,8 @@ func TestLdap(t *testing.T) {
 		cfg         *Config
 		user        string
 		id          string
+		groups      []string
+		attributes  []string
 		prepare     func(m *mockConn)
 	}{
 		{
@@ -119,6 +123,51 @@ func TestLdap(t *testing.T) {
 				m.On("Search").Return(result, nil)
 			},
 		},
+		{
+			name:        "it return error when StartTLS return error",
+			expectedErr: true,
+			cfg: &Config{
+				StartTLS: true,
+			},
+			prepare: func(m *mockConn) {
+				m.On("mockDial").Return(nil, nil)
+				m.On("StartTLS").Return(fmt.Errorf("StartTLS error"))
+			},
+		},
+		{
+			name:        "it return user groups from groups attribute",
+			expectedErr: false,
+			cfg: &Config{
+				StartTLS:        true,
+				Attributes:      []string{"uid", "mail"},
+				GroupsAttribute: "memberOf",
+			},
+			id:         "1",
+			user:       "test",
+			groups:     []string{"cn=admins,dc=example,dc=com"},
+			attributes: []string{"uid", "mail", "memberOf"},
+			prepare: func(m *mockConn) {
+				m.On("mockDial").Return(nil, nil)
+				m.On("StartTLS").Return(nil)
+				m.On("Bind").Return(nil)
+				m.On("UnauthenticatedBind").Return(nil)
+
+				entry := &ldap.Entry{
+					DN: "test",
+					Attributes: []*ldap.EntryAttribute{
+						ldap.NewEntryAttribute("uid", []string{"1"}),
+						ldap.NewEntryAttribute("mail", []string{"test@example.com"}),
+						ldap.NewEntryAttribute("memberOf", []string{"cn=admins,dc=example,dc=com"}),
+					},
+				}
+				result := &ldap.SearchResult{
+					Entries: []*ldap.Entry{
+						entry,
+					},
+				}
+				m.On("Search").Return(result, nil)
+			},
+		},
 	}
 
 	for _, tt := range table {
@@ -141,15 +190,73 @@ func TestLdap(t *testing.T) {
 			if !tt.expectedErr {
 				assert.Equal(t, tt.id, info.GetID())
 				assert.Equal(t, tt.user, info.GetUserName())
+				if tt.groups != nil {
+					assert.Equal(t, tt.groups, info.GetGroups())
+					assert.NotContains(t, info.GetExtensions(), "memberOf")
+				}
+				if tt.attributes != nil {
+					assert.Equal(t, tt.attributes, m.request.Attributes)
+					assert.Equal(t, []string{"uid", "mail"}, tt.cfg.Attributes)
+				}
 			}
 		})
 	}
 
 }
 
+func TestHealthCheck(t *testing.T) {
+	table := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(m *mockConn)
+	}{
+		{
+			name:        "it return error when dial return error",
+			expectedErr: true,
+			prepare: func(m *mockConn) {
+				m.On("mockDial").Return(nil, fmt.Errorf("mockDial error"))
+			},
+		},
+		{
+			name:        "it return error when bind return error",
+			expectedErr: true,
+			prepare: func(m *mockConn) {
+				m.On("mockDial").Return(nil, nil)
+				m.On("Bind").Return(fmt.Errorf("Bind error"))
+			},
+		},
+		{
+			name: "it return nil when search user bind succeed",
+			prepare: func(m *mockConn) {
+				m.On("mockDial").Return(nil, nil)
+				m.On("Bind").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockConn{
+				Mock: mock.Mock{},
+			}
+
+			tt.prepare(m)
+
+			c := client{
+				cfg:  &Config{BindPassword: "readonly"},
+				dial: m.mockDial,
+			}
+
+			err := c.healthCheck(context.Background())
+			assert.Equal(t, tt.expectedErr, err != nil)
+		})
+	}
+}
+
 func TestDial(t *testing.T) {
 	table := []struct {
 		newServer func(http.Handler) *httptest.Server
+		startTLS  bool
 		expectTLS bool
 	}{
 		{
@@ -160,6 +267,11 @@ func TestDial(t *testing.T) {
 			newServer: httptest.NewTLSServer,
 			expectTLS: true,
 		},
+		{
+			newServer: httptest.NewServer,
+			startTLS:  true,
+			expectTLS: false,
+		},
 	}
 
 	for _, tt := range table {
@@ -168,11 +280,15 @@ func TestDial(t *testing.T) {
 		if tt.expectTLS {
 			ts.TLS.InsecureSkipVerify = true
 		}
+		if tt.startTLS {
+			ts.TLS = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+		}
 		u, _ := url.Parse(ts.URL)
 		cfg := Config{
-			Port: u.Port(),
-			Host: u.Hostname(),
-			TLS:  ts.TLS,
+			Port:     u.Port(),
+			Host:     u.Hostname(),
+			TLS:      ts.TLS,
+			StartTLS: tt.startTLS,
 		}
 
 		c, err := dial(&cfg)
@@ -185,6 +301,7 @@ func TestDial(t *testing.T) {
 
 type mockConn struct {
 	mock.Mock
+	request *ldap.SearchRequest
 }
 
 func (m *mockConn) mockDial(cfg *Config) (conn, error) {
@@ -196,6 +313,7 @@ func (m *mockConn) Bind(username, password string) error {
 	return args.Error(0)
 }
 func (m *mockConn) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	m.request = searchRequest
 	args := m.Called()
 	return args.Get(0).(*ldap.SearchResult), args.Error(1)
 }
@@ -209,3 +327,25 @@ func (m *mockConn) UnauthenticatedBind(username string) error {
 }
 
 func (m *mockConn) Close() {}
+
+func TestHealthCheckContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	c := client{
+		cfg: &Config{},
+		dial: func(*Config) (conn, error) {
+			<-release
+			return nil, fmt.Errorf("mockDial error")
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	err := c.healthCheck(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	err = c.healthCheck(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -38,6 +38,13 @@ func New(addr string, c auth.Cache, opts ...auth.Option) auth.Strategy {
 	return token.New(fn, c, opts...)
 }
 
+// GetHealthCheckFunc return function to check the oauth2 token introspection endpoint reachable,
+// and accepts the configured client credentials, by introspecting a dummy token.
+func GetHealthCheckFunc(addr string, opts ...auth.Option) func(ctx context.Context) error {
+	intro := newIntrospection(addr, opts...)
+	return intro.healthCheck
+}
+
 func newIntrospection(addr string, opts ...auth.Option) *introspection {
 	r := internal.NewRequester(addr)
 	r.KeepUnmarshalling = true
@@ -101,3 +108,25 @@ func (i *introspection) authenticate(ctx context.Context, r *http.Request, token
 	token.WithNamedScopes(info, scope...)
 	return info, oauth2.ExpiresAt(claims), nil
 }
+
+func (i *introspection) healthCheck(ctx context.Context) error {
+	autherr := i.errorResolver.New()
+	authclaims := &claimsResponse{
+		ClaimsResolver: i.claimResolver.New(),
+	}
+
+	data := url.Values{}
+	data.Add("token", "healthcheck")
+
+	//nolint:bodyclose
+	resp, err := i.requester.Do(ctx, data, authclaims, autherr)
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("strategies/oauth2/introspection: %w", err)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("strategies/oauth2/introspection: %w", autherr)
+	}
+
+	return nil
+}
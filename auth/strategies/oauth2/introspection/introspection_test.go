@@ -3,13 +3,20 @@ package introspection
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
 )
 
 func TestIntrospection(t *testing.T) {
@@ -61,6 +68,76 @@ func TestIntrospection(t *testing.T) {
 	}
 }
 
+func TestIntrospectionCache(t *testing.T) {
+	calls := int32(0)
+	h := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = r.ParseForm()
+		if r.PostForm.Get("token") == "inactive" {
+			w.Write([]byte(`{"active":false}`))
+			return
+		}
+		exp := time.Now().Add(time.Hour).Unix()
+		fmt.Fprintf(w, `{"active":true, "username":"test", "exp":%d}`, exp)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(h))
+	defer srv.Close()
+
+	cache := libcache.LRU.New(0)
+	strategy := New(srv.URL, cache, token.SetHash(crypto.SHA256, []byte("key")))
+
+	for i := 0; i < 3; i++ {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", "Bearer active")
+		info, err := strategy.Authenticate(r.Context(), r)
+		assert.NoError(t, err)
+		assert.Equal(t, "test", info.GetUserName())
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, cache.Len())
+	assert.False(t, cache.Contains("active"), "raw token must not be used as cache key")
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer inactive")
+	_, err := strategy.Authenticate(r.Context(), r)
+	assert.Error(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestHealthCheck(t *testing.T) {
+	table := []struct {
+		name        string
+		code        int
+		file        string
+		expectedErr bool
+	}{
+		{
+			name:        "it return error when server return error status",
+			code:        401,
+			file:        "error_status",
+			expectedErr: true,
+		},
+		{
+			name: "it return nil when server introspect token",
+			code: 200,
+			file: "unauthorized_token",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mockAuthzServer(t, tt.file, tt.code)
+			defer srv.Close()
+			err := GetHealthCheckFunc(srv.URL)(context.TODO())
+			assert.Equal(t, tt.expectedErr, err != nil, err)
+		})
+	}
+
+	err := GetHealthCheckFunc("http://127.0.0.1:0")(context.TODO())
+	assert.Error(t, err)
+}
+
 func BenchmarkIntrospection(b *testing.B) {
 	r, _ := http.NewRequest("GET", "/", nil)
 	srv := mockAuthzServer(b, "user_token", 200)
@@ -2,7 +2,11 @@ package jwt
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"sync"
@@ -17,11 +21,13 @@ import (
 const cacheControl = "cache-control"
 
 type jwks struct {
-	mu        sync.Mutex
-	requester *internal.Requester
-	expiresAt time.Time
-	interval  time.Duration
-	keys      map[string]jose.JSONWebKey
+	mu          sync.Mutex
+	requester   *internal.Requester
+	expiresAt   time.Time
+	refreshedAt time.Time
+	interval    time.Duration
+	refreshRate time.Duration
+	keys        map[string]jose.JSONWebKey
 }
 
 func (j *jwks) KID() string {
@@ -29,11 +35,20 @@ func (j *jwks) KID() string {
 }
 
 func (j *jwks) Get(kid string) (interface{}, string, error) {
-	if err := j.load(); err != nil {
+	if err := j.load(false); err != nil {
 		return nil, "", err
 	}
 
-	v, ok := j.keys[kid]
+	v, ok := j.key(kid)
+
+	// kid not found, the authorization server might rotated its keys,
+	// reload the jwks before rejecting the token.
+	if !ok {
+		if err := j.load(true); err != nil {
+			return nil, "", err
+		}
+		v, ok = j.key(kid)
+	}
 
 	if !ok {
 		return nil, "", errors.New(
@@ -44,11 +59,26 @@ func (j *jwks) Get(kid string) (interface{}, string, error) {
 	return v.Key, v.Algorithm, nil
 }
 
-func (j *jwks) load() error {
+func (j *jwks) key(kid string) (jose.JSONWebKey, bool) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
+	v, ok := j.keys[kid]
+	return v, ok
+}
 
-	if time.Now().UTC().Before(j.expiresAt) {
+// load fetch the jwks from the authorization server when the cached keys expired,
+// or when force is true and the keys have not been refreshed within the refresh rate.
+func (j *jwks) load(force bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	if !force && now.Before(j.expiresAt) {
+		return nil
+	}
+
+	if force && now.Before(j.refreshedAt.Add(j.refreshRate)) {
 		return nil
 	}
 
@@ -61,15 +91,37 @@ func (j *jwks) load() error {
 		return err
 	}
 
+	keys := make(map[string]jose.JSONWebKey, len(kset.Keys))
 	for _, v := range kset.Keys {
-		j.keys[v.KeyID] = v
+		if len(v.Algorithm) == 0 {
+			v.Algorithm = algorithm(v.Key)
+		}
+		keys[v.KeyID] = v
 	}
 
+	j.keys = keys
+	j.refreshedAt = now
 	j.setExpiresAt(resp.Header)
 
 	return nil
 }
 
+func (j *jwks) healthCheck(ctx context.Context) error {
+	kset := new(jose.JSONWebKeySet)
+
+	//nolint:bodyclose
+	resp, err := j.requester.Do(ctx, nil, nil, kset)
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("strategies/oauth2/jwt: %w", err)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("strategies/oauth2/jwt: JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func (j *jwks) setExpiresAt(h http.Header) {
 	interval := j.interval
 
@@ -83,9 +135,29 @@ func (j *jwks) setExpiresAt(h http.Header) {
 	j.expiresAt = time.Now().Add(interval).UTC()
 }
 
+// algorithm return the default signing algorithm of the key,
+// for JWKS that omit the optional alg member e.g Azure AD.
+func algorithm(key interface{}) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return string(jose.RS256)
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return string(jose.ES256)
+		case elliptic.P384():
+			return string(jose.ES384)
+		case elliptic.P521():
+			return string(jose.ES512)
+		}
+	}
+	return ""
+}
+
 func newJWKS(addr string) *jwks {
 	j := new(jwks)
 	j.interval = time.Minute * 5
+	j.refreshRate = time.Second * 10
 	j.keys = make(map[string]jose.JSONWebKey)
 	j.requester = internal.NewRequester(addr)
 	j.requester.Method = http.MethodGet
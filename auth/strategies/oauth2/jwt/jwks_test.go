@@ -1,13 +1,24 @@
 package jwt
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/internal/jwt"
 )
 
 func TestJWKSKID(t *testing.T) {
@@ -50,6 +61,115 @@ func TestJWKSGet(t *testing.T) {
 	}
 }
 
+func TestJWKSDefaultAlgorithm(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	kset := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &rsaKey.PublicKey, KeyID: "rsa"},
+			{Key: &ecKey.PublicKey, KeyID: "ec"},
+			{Key: &rsaKey.PublicKey, KeyID: "ps", Algorithm: "PS256"},
+		},
+	}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(kset)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(h))
+	defer srv.Close()
+	jwks := newJWKS(srv.URL)
+
+	for kid, expected := range map[string]string{"rsa": "RS256", "ec": "ES384", "ps": "PS256"} {
+		_, alg, err := jwks.Get(kid)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, alg)
+	}
+}
+
+func TestJWKSRotation(t *testing.T) {
+	old, _ := rsa.GenerateKey(rand.Reader, 2048)
+	latest, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	mu := new(sync.Mutex)
+	counter := 0
+	kset := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &old.PublicKey, KeyID: "old", Algorithm: "RS256"},
+		},
+	}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		counter++
+		_ = json.NewEncoder(w).Encode(kset)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(h))
+	defer srv.Close()
+
+	s := newStrategy(srv.URL, SetInterval(time.Hour), SetRefreshRate(0))
+	exp := claims.Time(time.Now().Add(time.Hour))
+	c := &claims.Standard{Subject: "test", ExpiresAt: &exp}
+
+	str, err := jwt.IssueToken(rsaKeeper{"old", old}, c)
+	assert.NoError(t, err)
+	_, _, err = s.authenticate(context.TODO(), nil, str)
+	assert.NoError(t, err)
+
+	// rotate authorization server keys.
+	mu.Lock()
+	kset.Keys = []jose.JSONWebKey{
+		{Key: &latest.PublicKey, KeyID: "latest", Algorithm: "RS256"},
+	}
+	mu.Unlock()
+
+	str, err = jwt.IssueToken(rsaKeeper{"latest", latest}, c)
+	assert.NoError(t, err)
+	_, _, err = s.authenticate(context.TODO(), nil, str)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, counter)
+
+	// old key no longer published.
+	str, err = jwt.IssueToken(rsaKeeper{"old", old}, c)
+	assert.NoError(t, err)
+	_, _, err = s.authenticate(context.TODO(), nil, str)
+	assert.Error(t, err)
+}
+
+func TestJWKSRefreshRate(t *testing.T) {
+	counter := 0
+	srv := mockAuthzServer(t, "jwks.json", &counter)
+	defer srv.Close()
+	jwks := newJWKS(srv.URL)
+
+	for i := 0; i < 10; i++ {
+		_, _, err := jwks.Get("unknown")
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 1, counter)
+}
+
+func TestJWKSHealthCheck(t *testing.T) {
+	srv := mockAuthzServer(t, "jwks.json", nil)
+	err := GetHealthCheckFunc(srv.URL)(context.TODO())
+	assert.NoError(t, err)
+
+	srv.Close()
+	err = GetHealthCheckFunc(srv.URL)(context.TODO())
+	assert.Error(t, err)
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+	err = GetHealthCheckFunc(srv.URL)(context.TODO())
+	assert.EqualError(t, err, "strategies/oauth2/jwt: JWKS endpoint returned status 500")
+}
+
 func TestJWKSsetExpiresAt(t *testing.T) {
 	table := []struct {
 		name     string
@@ -122,3 +242,16 @@ func mockAuthzServer(tb testing.TB, file string, counter *int) *httptest.Server
 
 	return httptest.NewServer(http.HandlerFunc(h))
 }
+
+type rsaKeeper struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+func (r rsaKeeper) KID() string {
+	return r.kid
+}
+
+func (r rsaKeeper) Get(string) (interface{}, string, error) {
+	return r.key, "RS256", nil
+}
@@ -52,6 +52,12 @@ func New(addr string, c auth.Cache, opts ...auth.Option) auth.Strategy {
 	return token.New(fn, c, opts...)
 }
 
+// GetHealthCheckFunc return function to check the authorization server,
+// serves a valid JWKS at addr.
+func GetHealthCheckFunc(addr string, opts ...auth.Option) func(ctx context.Context) error {
+	return newStrategy(addr, opts...).jwks.healthCheck
+}
+
 func newStrategy(addr string, opts ...auth.Option) *strategy {
 	strategy := new(strategy)
 	strategy.jwks = newJWKS(addr)
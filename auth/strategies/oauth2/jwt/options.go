@@ -56,3 +56,14 @@ func SetInterval(d time.Duration) auth.Option {
 		}
 	})
 }
+
+// SetRefreshRate sets the minimum duration between two JWKS reloads,
+// triggered by tokens signed with an unknown key id (kid).
+// Default: 10 sec.
+func SetRefreshRate(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if s, ok := v.(*jwks); ok {
+			s.refreshRate = d
+		}
+	})
+}
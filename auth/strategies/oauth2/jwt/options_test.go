@@ -38,6 +38,12 @@ func TestSetInterval(t *testing.T) {
 	assert.Equal(t, time.Hour, s.jwks.interval)
 }
 
+func TestSetRefreshRate(t *testing.T) {
+	opt := SetRefreshRate(time.Hour)
+	s := newStrategy("", opt)
+	assert.Equal(t, time.Hour, s.jwks.refreshRate)
+}
+
 func TestSetClaimResolver(t *testing.T) {
 	opt := SetClaimResolver(nil)
 	s := newStrategy("", opt)
@@ -0,0 +1,29 @@
+package pkce_test
+
+import (
+	"fmt"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/pkce"
+)
+
+func Example() {
+	// client keep the verifier and send the challenge with the authorization request.
+	verifier := pkce.GenerateVerifier()
+	challenge := pkce.ChallengeS256(verifier)
+
+	// authorization server validate the verifier sent with the token request,
+	// against the stored challenge, before exchanging the authorization code.
+	fmt.Println(pkce.Validate(verifier, challenge))
+	fmt.Println(pkce.Validate(pkce.GenerateVerifier(), challenge))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleChallengeS256() {
+	fmt.Println(pkce.ChallengeS256("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+
+	// Output:
+	// E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM
+}
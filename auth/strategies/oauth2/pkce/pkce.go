@@ -0,0 +1,85 @@
+// Package pkce provides helpers for the OAuth 2.0 Proof Key for Code Exchange (PKCE),
+// as defined in RFC 7636, to protect public clients authorization code from interception.
+//
+// The client generates a code verifier and sends its S256 challenge with the authorization request,
+// and later sends the verifier with the token request, where the authorization server
+// validates the verifier against the stored challenge before exchanging the code.
+package pkce
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+const (
+	// MethodS256 is the code_challenge_method for SHA-256 challenges.
+	MethodS256 = "S256"
+	// MethodPlain is the code_challenge_method for plain challenges,
+	// RFC 7636 permit it only when the client cannot support S256.
+	MethodPlain = "plain"
+)
+
+// GenerateVerifier return a high-entropy code verifier,
+// a 43 characters base64url encoding of 32 random octets.
+func GenerateVerifier() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("strategies/oauth2/pkce: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// ChallengeS256 return the S256 code challenge of the verifier,
+// BASE64URL-ENCODE(SHA256(ASCII(code_verifier))).
+func ChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Validate reports whether the verifier is well-formed,
+// and its S256 challenge matches the challenge, using constant time comparison.
+func Validate(verifier, challenge string) bool {
+	return ValidateMethod(verifier, challenge, MethodS256)
+}
+
+// ValidateMethod reports whether the verifier is well-formed,
+// and match the challenge of the given code challenge method.
+// Unknown methods never validate.
+func ValidateMethod(verifier, challenge, method string) bool {
+	if !validVerifier(verifier) {
+		return false
+	}
+
+	var expected string
+	switch method {
+	case MethodS256:
+		expected = ChallengeS256(verifier)
+	case MethodPlain:
+		expected = verifier
+	default:
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// validVerifier reports whether the verifier is 43-128 characters
+// of the unreserved set [A-Z] / [a-z] / [0-9] / "-" / "." / "_" / "~".
+func validVerifier(v string) bool {
+	if len(v) < 43 || len(v) > 128 {
+		return false
+	}
+
+	for _, c := range v {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-', c == '.', c == '_', c == '~':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
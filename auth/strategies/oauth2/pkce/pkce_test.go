@@ -0,0 +1,102 @@
+package pkce
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 7636 Appendix B.
+const (
+	rfcVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	rfcChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestRFC7636Vectors(t *testing.T) {
+	octets := []byte{
+		116, 24, 223, 180, 151, 153, 224, 37, 79, 250, 96, 125, 216, 173,
+		187, 186, 22, 212, 37, 77, 105, 214, 191, 240, 91, 88, 5, 88, 83,
+		132, 141, 121,
+	}
+
+	assert.Equal(t, rfcVerifier, base64.RawURLEncoding.EncodeToString(octets))
+	assert.Equal(t, rfcChallenge, ChallengeS256(rfcVerifier))
+	assert.True(t, Validate(rfcVerifier, rfcChallenge))
+}
+
+func TestValidateMethod(t *testing.T) {
+	table := []struct {
+		name      string
+		verifier  string
+		challenge string
+		method    string
+		expected  bool
+	}{
+		{
+			name:      "it validate S256 challenge",
+			verifier:  rfcVerifier,
+			challenge: rfcChallenge,
+			method:    MethodS256,
+			expected:  true,
+		},
+		{
+			name:      "it validate plain challenge",
+			verifier:  rfcVerifier,
+			challenge: rfcVerifier,
+			method:    MethodPlain,
+			expected:  true,
+		},
+		{
+			name:      "it reject mismatched verifier",
+			verifier:  strings.Replace(rfcVerifier, "d", "e", 1),
+			challenge: rfcChallenge,
+			method:    MethodS256,
+		},
+		{
+			name:      "it reject plain verifier against S256 challenge",
+			verifier:  rfcVerifier,
+			challenge: rfcVerifier,
+			method:    MethodS256,
+		},
+		{
+			name:      "it reject short verifier",
+			verifier:  "short",
+			challenge: ChallengeS256("short"),
+			method:    MethodS256,
+		},
+		{
+			name:      "it reject long verifier",
+			verifier:  strings.Repeat("a", 129),
+			challenge: ChallengeS256(strings.Repeat("a", 129)),
+			method:    MethodS256,
+		},
+		{
+			name:      "it reject verifier with reserved characters",
+			verifier:  rfcVerifier + "+/",
+			challenge: ChallengeS256(rfcVerifier + "+/"),
+			method:    MethodS256,
+		},
+		{
+			name:      "it reject unknown method",
+			verifier:  rfcVerifier,
+			challenge: rfcChallenge,
+			method:    "S512",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ValidateMethod(tt.verifier, tt.challenge, tt.method))
+		})
+	}
+}
+
+func TestGenerateVerifier(t *testing.T) {
+	v := GenerateVerifier()
+	assert.Len(t, v, 43)
+	assert.True(t, validVerifier(v))
+	assert.NotEqual(t, v, GenerateVerifier())
+	assert.True(t, Validate(v, ChallengeS256(v)))
+}
@@ -0,0 +1,34 @@
+package state_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/state"
+)
+
+func Example() {
+	cache := libcache.LRU.New(0)
+
+	// login handler, redirect user agent to the authorization server with the state.
+	w := httptest.NewRecorder()
+	s := state.GenerateState()
+	state.StoreState(w, s, cache)
+
+	// callback handler.
+	r := httptest.NewRequest("GET", "/callback?code=xyz&state="+s, nil)
+	r.AddCookie(w.Result().Cookies()[0])
+
+	_, err := state.ValidateState(httptest.NewRecorder(), r, cache)
+	fmt.Println(err)
+
+	_, err = state.ValidateState(httptest.NewRecorder(), r, cache)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// strategies/oauth2/state: Invalid state
+}
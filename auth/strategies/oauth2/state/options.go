@@ -0,0 +1,17 @@
+package state
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetTTL sets how long a stored state remain valid.
+// Default Value 10 Minutes.
+func SetTTL(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*config); ok {
+			v.ttl = d
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTTL(t *testing.T) {
+	c := new(config)
+	opt := SetTTL(time.Minute)
+	opt.Apply(c)
+	assert.Equal(t, time.Minute, c.ttl)
+}
@@ -0,0 +1,113 @@
+// Package state provides helpers for the OAuth 2.0 authorization code flow state parameter,
+// to tie the authorization callback to the originating request and prevent CSRF,
+// as recommended in RFC 6749 section 10.12.
+//
+// The state stored in a short-TTL cache for one-time use,
+// and bound to the user agent using a cookie.
+package state
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+const (
+	// CookieName is the cookie name where the state bound to the user agent.
+	CookieName = "oauth2_state"
+	// QueryParam is the authorization callback query parameter holding the state.
+	QueryParam = "state"
+)
+
+var (
+	// ErrMissingState is returned by ValidateState,
+	// when the callback request missing the state query parameter or cookie.
+	ErrMissingState = errors.New("strategies/oauth2/state: Request missing state")
+
+	// ErrInvalidState is returned by ValidateState,
+	// when the state does not match the cookie, expired, or already used.
+	ErrInvalidState = errors.New("strategies/oauth2/state: Invalid state")
+)
+
+// mu serialize the state check-and-delete,
+// since auth.Cache does not provide an atomic load and delete,
+// hence a state can not be used by two concurrent callbacks.
+var mu sync.Mutex
+
+type config struct {
+	ttl time.Duration
+}
+
+// GenerateState return a base64url encoded 32 random octets state.
+func GenerateState() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("strategies/oauth2/state: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// StoreState stores the state in the cache with a short TTL,
+// and sets the state cookie to bind it to the user agent.
+// Default TTL 10 Minutes, use SetTTL to override it.
+func StoreState(w http.ResponseWriter, state string, c auth.Cache, opts ...auth.Option) {
+	cfg := &config{ttl: time.Minute * 10}
+	for _, opt := range opts {
+		opt.Apply(cfg)
+	}
+
+	c.StoreWithTTL(state, struct{}{}, cfg.ttl)
+	http.SetCookie(w, stateCookie(state, int(cfg.ttl.Seconds())))
+}
+
+func stateCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ValidateState checks the callback request state query parameter against the state cookie and the cache,
+// and deletes the state from the cache and expires the state cookie on success, hence it can not be replayed.
+// ValidateState return the validated state.
+func ValidateState(w http.ResponseWriter, r *http.Request, c auth.Cache) (string, error) {
+	state, err := internal.ParseQuery(QueryParam, r, ErrMissingState)
+	if err != nil {
+		return "", err
+	}
+
+	cookie, err := internal.ParseCookie(CookieName, r, ErrMissingState)
+	if err != nil {
+		return "", ErrMissingState
+	}
+
+	if cookie != state {
+		return "", ErrInvalidState
+	}
+
+	mu.Lock()
+	_, ok := c.Load(state)
+	if ok {
+		c.Delete(state)
+	}
+	mu.Unlock()
+
+	if !ok {
+		return "", ErrInvalidState
+	}
+
+	http.SetCookie(w, stateCookie("", -1))
+
+	return state, nil
+}
@@ -0,0 +1,102 @@
+package state
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func callback(state string, cookie *http.Cookie) *http.Request {
+	r := httptest.NewRequest("GET", "/callback?code=xyz&state="+state, nil)
+	if cookie != nil {
+		r.AddCookie(cookie)
+	}
+	return r
+}
+
+func TestValidateState(t *testing.T) {
+	cache := libcache.LRU.New(0)
+
+	store := func() (string, *http.Cookie) {
+		w := httptest.NewRecorder()
+		state := GenerateState()
+		StoreState(w, state, cache, SetTTL(time.Millisecond*20))
+		return state, w.Result().Cookies()[0]
+	}
+
+	// Round #1 valid state passes.
+	state, cookie := store()
+	assert.True(t, cookie.HttpOnly)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+	w := httptest.NewRecorder()
+	got, err := ValidateState(w, callback(state, cookie), cache)
+	assert.NoError(t, err)
+	assert.Equal(t, state, got)
+
+	expired := w.Result().Cookies()
+	assert.Len(t, expired, 1)
+	assert.Equal(t, CookieName, expired[0].Name)
+	assert.Empty(t, expired[0].Value)
+	assert.True(t, expired[0].MaxAge < 0)
+
+	// Round #2 replayed state fails.
+	_, err = ValidateState(httptest.NewRecorder(), callback(state, cookie), cache)
+	assert.Equal(t, ErrInvalidState, err)
+
+	// Round #3 expired state fails.
+	state, cookie = store()
+	time.Sleep(time.Millisecond * 30)
+	_, err = ValidateState(httptest.NewRecorder(), callback(state, cookie), cache)
+	assert.Equal(t, ErrInvalidState, err)
+
+	// Round #4 state of another user agent fails.
+	state, _ = store()
+	_, other := store()
+	_, err = ValidateState(httptest.NewRecorder(), callback(state, other), cache)
+	assert.Equal(t, ErrInvalidState, err)
+
+	// Round #5 missing state or cookie fails.
+	_, err = ValidateState(httptest.NewRecorder(), callback("", cookie), cache)
+	assert.Equal(t, ErrMissingState, err)
+	_, err = ValidateState(httptest.NewRecorder(), callback(state, nil), cache)
+	assert.Equal(t, ErrMissingState, err)
+}
+
+func TestValidateStateConcurrent(t *testing.T) {
+	cache := libcache.LRU.New(0)
+	w := httptest.NewRecorder()
+	state := GenerateState()
+	StoreState(w, state, cache)
+	cookie := w.Result().Cookies()[0]
+
+	var valid int32
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ValidateState(httptest.NewRecorder(), callback(state, cookie), cache); err == nil {
+				atomic.AddInt32(&valid, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), valid)
+}
+
+func TestGenerateState(t *testing.T) {
+	s := GenerateState()
+	assert.Len(t, s, 43)
+	assert.NotEqual(t, s, GenerateState())
+}
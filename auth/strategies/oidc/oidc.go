@@ -0,0 +1,254 @@
+// Package oidc provide auth strategy to authenticate,
+// incoming HTTP requests using OpenID Connect ID tokens,
+// verified against the keys discovered from the issuer,
+// as defined in OpenID Connect Discovery https://openid.net/specs/openid-connect-discovery-1_0.html.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+	"github.com/shaj13/go-guardian/v2/auth/internal/flight"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// ClaimsMapper maps the verified ID token claims to user info,
+// commonly used to map provider specific claims.
+type ClaimsMapper func(claims map[string]interface{}) auth.Info
+
+// DefaultClaimsMapper maps the ID token claims to user info,
+// where the user name is the preferred_username, email or sub claim,
+// the id is the sub claim, and groups are the groups claim.
+func DefaultClaimsMapper(c map[string]interface{}) auth.Info {
+	str := func(key string) string {
+		v, _ := c[key].(string)
+		return v
+	}
+
+	name := str("preferred_username")
+	if len(name) == 0 {
+		name = str("email")
+	}
+	if len(name) == 0 {
+		name = str("sub")
+	}
+
+	groups := []string{}
+	if v, ok := c["groups"].([]interface{}); ok {
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	}
+
+	ext := auth.Extensions{}
+	if email := str("email"); len(email) > 0 {
+		ext.Set("email", email)
+	}
+
+	return auth.NewUserInfo(name, str("sub"), groups, ext)
+}
+
+// idToken represents ID token claims.
+// idToken implements oauth2.ClaimsResolver.
+type idToken struct {
+	*claims.Standard
+	raw    map[string]interface{}
+	mapper ClaimsMapper
+}
+
+func (t *idToken) New() oauth2.ClaimsResolver {
+	return &idToken{Standard: new(claims.Standard), mapper: t.mapper}
+}
+
+func (t *idToken) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, t.Standard); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, &t.raw)
+}
+
+func (t *idToken) Verify(opts claims.VerifyOptions) error {
+	if t.ExpiresAt == nil || len(t.Subject) == 0 {
+		return errors.New("strategies/oidc: ID token missing exp or sub claim")
+	}
+	return t.Standard.Verify(opts)
+}
+
+func (t *idToken) GetExpiresAt() time.Time {
+	return time.Time(*t.ExpiresAt)
+}
+
+func (t *idToken) Resolve() auth.Info {
+	return t.mapper(t.raw)
+}
+
+// Configuration represents the discovered OpenID provider configuration.
+type Configuration struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// ConfigurationVerifier declare function signature to verify the discovered configuration,
+// and return the oauth2 jwt strategy options to verify the tokens,
+// e.g jwt.SetClaimResolver and jwt.SetVerifyOptions to verify provider specific claims.
+type ConfigurationVerifier func(cfg Configuration) ([]auth.Option, error)
+
+type oidc struct {
+	mu        sync.RWMutex
+	flight    flight.Group
+	requester *internal.Requester
+	issuer    string
+	clientID  string
+	mapper    ClaimsMapper
+	verifier  ConfigurationVerifier
+	opts      []auth.Option
+	fn        token.AuthenticateFunc
+}
+
+func (o *oidc) authenticate(ctx context.Context, r *http.Request, tokenstr string) (auth.Info, time.Time, error) { //nolint:lll
+	fn, err := o.discover(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return fn(ctx, r, tokenstr)
+}
+
+// discover fetch the issuer openid configuration once succeeded,
+// and return the jwt authenticate function using the discovered jwks.
+// Concurrent callers share a single in-flight discovery request.
+func (o *oidc) discover(ctx context.Context) (token.AuthenticateFunc, error) {
+	o.mu.RLock()
+	fn := o.fn
+	o.mu.RUnlock()
+
+	if fn != nil {
+		return fn, nil
+	}
+
+	v, err := o.flight.Do("", func() (interface{}, error) {
+		// detach the shared discovery from the first caller cancellation.
+		ctx, cancel := flight.Detach(ctx)
+		defer cancel()
+		return o.fetch(ctx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(token.AuthenticateFunc), nil
+}
+
+func (o *oidc) fetch(ctx context.Context) (token.AuthenticateFunc, error) {
+	o.mu.RLock()
+	fn := o.fn
+	o.mu.RUnlock()
+
+	// discovered by a previous in-flight call.
+	if fn != nil {
+		return fn, nil
+	}
+
+	cfg := Configuration{}
+
+	//nolint:bodyclose
+	resp, err := o.requester.Do(ctx, nil, &cfg, nil)
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("strategies/oidc: %w", err)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("strategies/oidc: OpenID configuration endpoint returned %v status code", resp.StatusCode)
+	case len(cfg.JWKSURI) == 0:
+		return nil, fmt.Errorf("strategies/oidc: OpenID configuration missing jwks_uri")
+	}
+
+	verified, err := o.verifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]auth.Option{
+		jwt.SetClaimResolver(&idToken{mapper: o.mapper}),
+		jwt.SetVerifyOptions(claims.VerifyOptions{
+			Audience: []string{o.clientID},
+			Issuer:   cfg.Issuer,
+		}),
+	}, verified...)
+	opts = append(opts, o.opts...)
+
+	fn = jwt.GetAuthenticateFunc(cfg.JWKSURI, opts...)
+
+	o.mu.Lock()
+	o.fn = fn
+	o.mu.Unlock()
+
+	return fn, nil
+}
+
+// verifyIssuer is the default ConfigurationVerifier,
+// that verify the configuration issuer matches the strategy issuer.
+func (o *oidc) verifyIssuer(cfg Configuration) ([]auth.Option, error) {
+	if cfg.Issuer != o.issuer {
+		return nil, fmt.Errorf("strategies/oidc: OpenID configuration issuer %s does not match %s", cfg.Issuer, o.issuer)
+	}
+	return nil, nil
+}
+
+// GetAuthenticateFunc return function to authenticate request using OpenID Connect ID tokens,
+// issued by the issuer to the client id.
+// The returned function typically used with the token strategy.
+func GetAuthenticateFunc(issuer, clientID string, opts ...auth.Option) token.AuthenticateFunc {
+	return newOIDC(issuer, clientID, opts...).authenticate
+}
+
+// New return strategy authenticate request using OpenID Connect ID tokens,
+// issued by the issuer to the client id.
+// The issuer openid configuration discovered on the first authentication,
+// and retried on subsequent authentications until succeeded.
+// The discovered JWKS cached and reloaded when a token signed by an unknown key.
+//
+// New is similar to:
+//
+// 		fn := oidc.GetAuthenticateFunc(issuer, clientID, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func New(issuer, clientID string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	fn := GetAuthenticateFunc(issuer, clientID, opts...)
+	return token.New(fn, c, opts...)
+}
+
+func newOIDC(issuer, clientID string, opts ...auth.Option) *oidc {
+	r := internal.NewRequester(strings.TrimSuffix(issuer, "/"))
+	r.Endpoint = "/.well-known/openid-configuration"
+	r.Method = http.MethodGet
+	r.SetHeader("Accept", "application/json")
+
+	o := new(oidc)
+	o.requester = r
+	o.issuer = issuer
+	o.clientID = clientID
+	o.mapper = DefaultClaimsMapper
+	o.verifier = o.verifyIssuer
+	o.opts = opts
+
+	for _, opt := range opts {
+		opt.Apply(o)
+		opt.Apply(o.requester)
+	}
+
+	return o
+}
@@ -0,0 +1,285 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+)
+
+const issuer = "https://issuer.example.com"
+
+func TestOIDC(t *testing.T) {
+	srv := mockProvider(t, issuer, new(int), false)
+	defer srv.Close()
+
+	table := []struct {
+		name        string
+		file        string
+		expectedErr string
+		info        auth.Info
+	}{
+		{
+			name: "it return user info when id token valid",
+			file: "id_token",
+			info: auth.NewUserInfo("jane", "248289761001", []string{"admin", "dev"}, auth.Extensions{"email": {"jane@example.com"}}),
+		},
+		{
+			name: "it return user info with email as name when preferred username missing",
+			file: "email_only_id_token",
+			info: auth.NewUserInfo("jane@example.com", "248289761001", []string{}, auth.Extensions{"email": {"jane@example.com"}}),
+		},
+		{
+			name:        "it return error when id token intended for another client",
+			file:        "invalid_audience_id_token",
+			expectedErr: "audience",
+		},
+		{
+			name:        "it return error when id token issued by another issuer",
+			file:        "invalid_issuer_id_token",
+			expectedErr: "issuer",
+		},
+		{
+			name:        "it return error when id token expired",
+			file:        "expired_id_token",
+			expectedErr: "expired",
+		},
+		{
+			name:        "it return error when id token missing exp",
+			file:        "missing_exp_id_token",
+			expectedErr: "missing exp",
+		},
+		{
+			name:        "it return error when id token signed by unknown key",
+			file:        "unknown_kid_id_token",
+			expectedErr: "Invalid kid-2 KID",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOIDC(issuer, "client-id")
+			o.requester.Addr = srv.URL
+			info, _, err := o.authenticate(context.TODO(), nil, readFile(t, tt.file))
+
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.info.GetUserName(), info.GetUserName())
+			assert.Equal(t, tt.info.GetID(), info.GetID())
+			assert.Equal(t, tt.info.GetGroups(), info.GetGroups())
+			assert.Equal(t, tt.info.GetExtensions().Get("email"), info.GetExtensions().Get("email"))
+		})
+	}
+}
+
+func TestOIDCDiscoveryIssuerMismatch(t *testing.T) {
+	srv := mockProvider(t, "https://other.example.com", new(int), false)
+	defer srv.Close()
+
+	o := newOIDC(issuer, "client-id")
+	o.requester.Addr = srv.URL
+	_, _, err := o.authenticate(context.TODO(), nil, readFile(t, "id_token"))
+
+	assert.Contains(t, err.Error(), "does not match")
+}
+
+func TestOIDCKeyRotation(t *testing.T) {
+	calls := 0
+	srv := mockProvider(t, issuer, &calls, true)
+	defer srv.Close()
+
+	// the first jwks response does not include the signing key,
+	// the strategy must reload the jwks on key not found.
+	o := newOIDC(issuer, "client-id", jwt.SetRefreshRate(0))
+	o.requester.Addr = srv.URL
+	_, _, err := o.authenticate(context.TODO(), nil, readFile(t, "id_token"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestOIDCConcurrentDiscovery(t *testing.T) {
+	keys := readFile(t, "jwks.json")
+	discoveries := int32(0)
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&discoveries, 1)
+		time.Sleep(time.Millisecond * 50)
+		_, _ = w.Write([]byte(`{"issuer":"` + issuer + `","jwks_uri":"` + srv.URL + `/jwks"}`))
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(keys))
+	})
+
+	o := newOIDC(issuer, "client-id")
+	o.requester.Addr = srv.URL
+	tk := readFile(t, "id_token")
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := o.authenticate(context.TODO(), nil, tk)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&discoveries))
+}
+
+func TestOIDCDiscoveryFirstCallerCanceled(t *testing.T) {
+	keys := readFile(t, "jwks.json")
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{"issuer":"` + issuer + `","jwks_uri":"` + srv.URL + `/jwks"}`))
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(keys))
+	})
+
+	o := newOIDC(issuer, "client-id")
+	o.requester.Addr = srv.URL
+	tk := readFile(t, "id_token")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		_, _, _ = o.authenticate(ctx, nil, tk)
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	second := make(chan error)
+	go func() {
+		_, _, err := o.authenticate(context.Background(), nil, tk)
+		second <- err
+	}()
+	time.Sleep(time.Millisecond * 20)
+
+	cancel()
+	time.Sleep(time.Millisecond * 20)
+	close(release)
+
+	assert.NoError(t, <-second)
+	<-first
+}
+
+func TestSetConfigurationVerifier(t *testing.T) {
+	const other = "https://other.example.com"
+	srv := mockProvider(t, other, new(int), false)
+	defer srv.Close()
+
+	table := []struct {
+		name        string
+		verifier    ConfigurationVerifier
+		expectedErr string
+	}{
+		{
+			name: "it verify tokens using the verifier options",
+			verifier: func(cfg Configuration) ([]auth.Option, error) {
+				assert.Equal(t, other, cfg.Issuer)
+				return []auth.Option{
+					jwt.SetVerifyOptions(claims.VerifyOptions{
+						Audience: []string{"client-id"},
+						Issuer:   issuer,
+					}),
+				}, nil
+			},
+		},
+		{
+			name: "it return the verifier error",
+			verifier: func(cfg Configuration) ([]auth.Option, error) {
+				return nil, errors.New("untrusted issuer")
+			},
+			expectedErr: "untrusted issuer",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOIDC(issuer, "client-id", SetConfigurationVerifier(tt.verifier))
+			o.requester.Addr = srv.URL
+			_, _, err := o.authenticate(context.TODO(), nil, readFile(t, "id_token"))
+
+			if len(tt.expectedErr) > 0 {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSetClaimsMapper(t *testing.T) {
+	srv := mockProvider(t, issuer, new(int), false)
+	defer srv.Close()
+
+	mapper := func(c map[string]interface{}) auth.Info {
+		return auth.NewUserInfo(c["email"].(string), c["sub"].(string), nil, nil)
+	}
+
+	o := newOIDC(issuer, "client-id", SetClaimsMapper(mapper))
+	o.requester.Addr = srv.URL
+	info, _, err := o.authenticate(context.TODO(), nil, readFile(t, "id_token"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "jane@example.com", info.GetUserName())
+}
+
+func readFile(tb testing.TB, file string) string {
+	b, err := ioutil.ReadFile("./testdata/" + file)
+	if err != nil {
+		tb.Fatalf("Failed to read testdata file Err: %s", err)
+	}
+	return string(b)
+}
+
+// mockProvider mock the provider discovery and jwks endpoints,
+// where calls counts jwks requests, and the first one served empty when rotate.
+func mockProvider(tb testing.TB, iss string, calls *int, rotate bool) *httptest.Server {
+	keys := readFile(tb, "jwks.json")
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issuer":"` + iss + `","jwks_uri":"` + srv.URL + `/jwks"}`))
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if rotate && *calls == 1 {
+			_, _ = w.Write([]byte(`{"keys":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(keys))
+	})
+
+	return srv
+}
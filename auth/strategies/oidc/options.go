@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+// SetHTTPClient sets underlying http client,
+// used for both discovery and jwks requests.
+func SetHTTPClient(c *http.Client) auth.Option {
+	return internal.SetRequesterHTTPClient(c)
+}
+
+// SetTLSConfig sets underlying http client tls.
+func SetTLSConfig(tls *tls.Config) auth.Option {
+	return internal.SetRequesterTLSConfig(tls)
+}
+
+// SetClientTransport sets underlying http client transport.
+func SetClientTransport(rt http.RoundTripper) auth.Option {
+	return internal.SetRequesterClientTransport(rt)
+}
+
+// SetClaimsMapper sets the function to map ID token claims to user info.
+// Default: DefaultClaimsMapper
+func SetClaimsMapper(m ClaimsMapper) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if o, ok := v.(*oidc); ok {
+			o.mapper = m
+		}
+	})
+}
+
+// SetConfigurationVerifier sets the function to verify the discovered OpenID configuration,
+// commonly used by providers where the configuration issuer differ from the discovery issuer,
+// e.g multi-tenant providers.
+// Default: verify the configuration issuer matches the strategy issuer.
+func SetConfigurationVerifier(fn ConfigurationVerifier) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if o, ok := v.(*oidc); ok {
+			o.verifier = fn
+		}
+	})
+}
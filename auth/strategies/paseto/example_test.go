@@ -0,0 +1,56 @@
+package paseto_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/paseto"
+)
+
+func ExampleNewLocal() {
+	u := auth.NewUserInfo("example", "example", nil, nil)
+	c := libcache.LRU.New(0)
+	key := [32]byte{} // your 32 byte secret key
+
+	token, err := paseto.IssueLocalToken(u, key)
+	strategy := paseto.NewLocal(c, key)
+
+	fmt.Println(err)
+
+	// user request
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	user, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(user.GetID(), err)
+
+	// Output:
+	// <nil>
+	// example <nil>
+}
+
+func ExampleNewPublic() {
+	u := auth.NewUserInfo("example", "example", nil, nil)
+	c := libcache.LRU.New(0)
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	token, err := paseto.IssuePublicToken(u, priv)
+	strategy := paseto.NewPublic(c, pub)
+
+	fmt.Println(err)
+
+	// user request
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	user, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(user.GetID(), err)
+
+	// Output:
+	// <nil>
+	// example <nil>
+}
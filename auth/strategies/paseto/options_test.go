@@ -0,0 +1,32 @@
+package paseto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAudience(t *testing.T) {
+	opt := SetAudience("test")
+	tk := newAccessToken(nil, opt)
+	assert.Equal(t, "test", tk.aud)
+}
+
+func TestSetIssuer(t *testing.T) {
+	opt := SetIssuer("test")
+	tk := newAccessToken(nil, opt)
+	assert.Equal(t, "test", tk.iss)
+}
+
+func TestSetExpDuration(t *testing.T) {
+	opt := SetExpDuration(time.Hour)
+	tk := newAccessToken(nil, opt)
+	assert.Equal(t, time.Hour, tk.dur)
+}
+
+func TestSetNamedScopes(t *testing.T) {
+	opt := SetNamedScopes("read:repo")
+	tk := newAccessToken(nil, opt)
+	assert.Equal(t, []string{"read:repo"}, tk.scp)
+}
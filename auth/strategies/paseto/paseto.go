@@ -0,0 +1,76 @@
+// Package paseto provides authentication strategy,
+// to authenticate HTTP requests based on paseto (Platform-Agnostic Security Tokens) version 2,
+// both v2.local (symmetric XChaCha20-Poly1305) and v2.public (asymmetric Ed25519) purposes.
+package paseto
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// GetLocalAuthenticateFunc return function to authenticate request using paseto v2.local token.
+// The returned function typically used with the token strategy.
+func GetLocalAuthenticateFunc(key [32]byte, opts ...auth.Option) token.AuthenticateFunc {
+	return getAuthenticateFunc(local{key: key}, opts...)
+}
+
+// GetPublicAuthenticateFunc return function to authenticate request using paseto v2.public token.
+// The returned function typically used with the token strategy.
+func GetPublicAuthenticateFunc(pub ed25519.PublicKey, opts ...auth.Option) token.AuthenticateFunc {
+	return getAuthenticateFunc(public{pub: pub}, opts...)
+}
+
+// NewLocal return strategy authenticate request using paseto v2.local token.
+//
+// NewLocal is similar to:
+//
+// 		fn := paseto.GetLocalAuthenticateFunc(key, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func NewLocal(c auth.Cache, key [32]byte, opts ...auth.Option) auth.Strategy {
+	fn := GetLocalAuthenticateFunc(key, opts...)
+	return token.New(fn, c, opts...)
+}
+
+// NewPublic return strategy authenticate request using paseto v2.public token.
+//
+// NewPublic is similar to:
+//
+// 		fn := paseto.GetPublicAuthenticateFunc(pub, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func NewPublic(c auth.Cache, pub ed25519.PublicKey, opts ...auth.Option) auth.Strategy {
+	fn := GetPublicAuthenticateFunc(pub, opts...)
+	return token.New(fn, c, opts...)
+}
+
+// IssueLocalToken issue paseto v2.local token for the provided user info.
+func IssueLocalToken(info auth.Info, key [32]byte, opts ...auth.Option) (string, error) {
+	return newAccessToken(local{key: key}, opts...).issue(info)
+}
+
+// IssuePublicToken issue paseto v2.public token for the provided user info.
+func IssuePublicToken(info auth.Info, priv ed25519.PrivateKey, opts ...auth.Option) (string, error) {
+	return newAccessToken(public{priv: priv}, opts...).issue(info)
+}
+
+func getAuthenticateFunc(p protocol, opts ...auth.Option) token.AuthenticateFunc {
+	t := newAccessToken(p, opts...)
+	return func(ctx context.Context, r *http.Request, tk string) (auth.Info, time.Time, error) {
+		c, info, err := t.parse(tk)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		if len(c.Scope) > 0 {
+			token.WithNamedScopes(info, c.Scope.Split()...)
+		}
+
+		return info, time.Time(*c.ExpiresAt), nil
+	}
+}
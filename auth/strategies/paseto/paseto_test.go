@@ -0,0 +1,99 @@
+package paseto
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func TestStrategy(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	key := [32]byte{}
+	_, _ = rand.Read(key[:])
+	info := auth.NewDefaultUser("test", "test-id", []string{"admin"}, nil)
+
+	local, err := IssueLocalToken(info, key, SetIssuer("test-iss"))
+	assert.NoError(t, err)
+
+	public, err := IssuePublicToken(info, priv, SetIssuer("test-iss"))
+	assert.NoError(t, err)
+
+	expired, err := IssueLocalToken(info, key, SetExpDuration(-time.Hour))
+	assert.NoError(t, err)
+
+	table := []struct {
+		name        string
+		strategy    auth.Strategy
+		token       string
+		expectedErr bool
+	}{
+		{
+			name:     "it authenticate v2.local token",
+			strategy: NewLocal(libcache.LRU.New(0), key, SetIssuer("test-iss")),
+			token:    local,
+		},
+		{
+			name:     "it authenticate v2.public token",
+			strategy: NewPublic(libcache.LRU.New(0), pub, SetIssuer("test-iss")),
+			token:    public,
+		},
+		{
+			name:        "it return error when token issuer does not match",
+			strategy:    NewLocal(libcache.LRU.New(0), key, SetIssuer("other-iss")),
+			token:       local,
+			expectedErr: true,
+		},
+		{
+			name:        "it return error when token expired",
+			strategy:    NewLocal(libcache.LRU.New(0), key),
+			token:       expired,
+			expectedErr: true,
+		},
+		{
+			name:        "it return error when v2.local token sent to v2.public strategy",
+			strategy:    NewPublic(libcache.LRU.New(0), pub),
+			token:       local,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			got, err := tt.strategy.Authenticate(r.Context(), r)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, info.GetUserName(), got.GetUserName())
+			assert.Equal(t, info.GetID(), got.GetID())
+			assert.Equal(t, info.GetGroups(), got.GetGroups())
+		})
+	}
+}
+
+func TestStrategyScopes(t *testing.T) {
+	key := [32]byte{}
+	info := auth.NewDefaultUser("test", "test-id", nil, nil)
+	tk, err := IssueLocalToken(info, key, SetNamedScopes("read:repo"))
+	assert.NoError(t, err)
+
+	fn := GetLocalAuthenticateFunc(key)
+	got, _, err := fn(context.TODO(), nil, tk)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read:repo"}, token.GetNamedScopes(got))
+}
@@ -0,0 +1,176 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	headerLocal  = "v2.local."
+	headerPublic = "v2.public."
+)
+
+var (
+	// ErrInvalidToken is returned by Authenticate Strategy method,
+	// when paseto token malformed or its version/purpose not supported.
+	ErrInvalidToken = errors.New("strategies/paseto: Invalid token")
+
+	// ErrInvalidSignature is returned by Authenticate Strategy method,
+	// when paseto token authentication tag or signature verification failed.
+	ErrInvalidSignature = errors.New("strategies/paseto: Invalid token signature")
+)
+
+var b64 = base64.RawURLEncoding
+
+// protocol seal and open paseto v2 token message.
+type protocol interface {
+	seal(msg, footer []byte) (string, error)
+	open(token string) (msg []byte, err error)
+}
+
+type local struct {
+	key [32]byte
+}
+
+func (l local) seal(msg, footer []byte) (string, error) {
+	b := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	// as described in paseto v2 spec the nonce is derived using BLAKE2b,
+	// to protect against weak random number generators.
+	h, err := blake2b.New(chacha20poly1305.NonceSizeX, b)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = h.Write(msg)
+	nonce := h.Sum(nil)
+
+	aead, err := chacha20poly1305.NewX(l.key[:])
+	if err != nil {
+		return "", err
+	}
+
+	ad := pae([]byte(headerLocal), nonce, footer)
+	body := aead.Seal(nonce, nonce, msg, ad)
+
+	return encode(headerLocal, body, footer), nil
+}
+
+func (l local) open(token string) ([]byte, error) {
+	body, footer, err := decode(headerLocal, token)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(l.key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidToken
+	}
+
+	nonce, cipher := body[:chacha20poly1305.NonceSizeX], body[chacha20poly1305.NonceSizeX:]
+	ad := pae([]byte(headerLocal), nonce, footer)
+	msg, err := aead.Open(nil, nonce, cipher, ad)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	return msg, nil
+}
+
+type public struct {
+	priv ed25519.PrivateKey
+	pub  ed25519.PublicKey
+}
+
+func (p public) seal(msg, footer []byte) (string, error) {
+	if len(p.priv) != ed25519.PrivateKeySize {
+		return "", errors.New("strategies/paseto: Invalid ed25519 private key size")
+	}
+
+	sig := ed25519.Sign(p.priv, pae([]byte(headerPublic), msg, footer))
+	body := append(append([]byte{}, msg...), sig...)
+	return encode(headerPublic, body, footer), nil
+}
+
+func (p public) open(token string) ([]byte, error) {
+	body, footer, err := decode(headerPublic, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) < ed25519.SignatureSize || len(p.pub) != ed25519.PublicKeySize {
+		return nil, ErrInvalidToken
+	}
+
+	msg, sig := body[:len(body)-ed25519.SignatureSize], body[len(body)-ed25519.SignatureSize:]
+	if !ed25519.Verify(p.pub, pae([]byte(headerPublic), msg, footer), sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	return msg, nil
+}
+
+func encode(header string, body, footer []byte) string {
+	token := header + b64.EncodeToString(body)
+	if len(footer) > 0 {
+		token += "." + b64.EncodeToString(footer)
+	}
+	return token
+}
+
+func decode(header, token string) (body, footer []byte, err error) {
+	if len(token) < len(header) ||
+		subtle.ConstantTimeCompare([]byte(header), []byte(token[:len(header)])) != 1 {
+		return nil, nil, ErrInvalidToken
+	}
+
+	parts := strings.Split(token[len(header):], ".")
+	if len(parts) > 2 {
+		return nil, nil, ErrInvalidToken
+	}
+
+	if body, err = b64.DecodeString(parts[0]); err != nil {
+		return nil, nil, ErrInvalidToken
+	}
+
+	if len(parts) == 2 {
+		if footer, err = b64.DecodeString(parts[1]); err != nil {
+			return nil, nil, ErrInvalidToken
+		}
+	}
+
+	return body, footer, nil
+}
+
+// pae implements paseto Pre-Authentication Encoding (PAE).
+func pae(pieces ...[]byte) []byte {
+	le64 := func(n int) []byte {
+		b := make([]byte, 8)
+		// clear the MSB for interoperability.
+		binary.LittleEndian.PutUint64(b, uint64(n)&^(1<<63))
+		return b
+	}
+
+	out := le64(len(pieces))
+	for _, p := range pieces {
+		out = append(out, le64(len(p))...)
+		out = append(out, p...)
+	}
+
+	return out
+}
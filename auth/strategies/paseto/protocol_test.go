@@ -0,0 +1,157 @@
+package paseto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPAE(t *testing.T) {
+	table := []struct {
+		pieces   [][]byte
+		expected string
+	}{
+		{
+			pieces:   [][]byte{},
+			expected: "\x00\x00\x00\x00\x00\x00\x00\x00",
+		},
+		{
+			pieces:   [][]byte{[]byte("")},
+			expected: "\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
+		},
+		{
+			pieces:   [][]byte{[]byte("test")},
+			expected: "\x01\x00\x00\x00\x00\x00\x00\x00\x04\x00\x00\x00\x00\x00\x00\x00test",
+		},
+	}
+
+	for _, tt := range table {
+		assert.Equal(t, tt.expected, string(pae(tt.pieces...)))
+	}
+}
+
+func TestPublicVector(t *testing.T) {
+	// test vector 2-S-1 from paseto v2 spec.
+	seed, _ := hex.DecodeString("b4cbfb43df4ce210727d953e4a713307fa19bb7d9f85041438d9e11b942a3774")
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
+	msg := []byte(`{"data":"this is a signed message","exp":"2019-01-01T00:00:00+00:00"}`)
+	expected := "v2.public.eyJkYXRhIjoidGhpcyBpcyBhIHNpZ25lZCBtZXNzYWdlIiwiZXhwIjoiMjAxOS0wMS0wMVQwMDowMDowMCswMDowMCJ9HQr8URrGntTu7Dz9J2IF23d1M7-9lH9xiqdGyJNvzp4angPW5Esc7C5huy_M8I8_DjJK2ZXC2SUYuOFM-Q_5Cw" //nolint:lll
+
+	token, err := public{priv: priv}.seal(msg, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, token)
+
+	got, err := public{pub: pub}.open(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, got)
+}
+
+func TestProtocol(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	key := [32]byte{1, 2, 3}
+	otherKey := [32]byte{3, 2, 1}
+	msg := []byte(`{"sub":"test"}`)
+
+	table := []struct {
+		name        string
+		sealer      protocol
+		opener      protocol
+		footer      []byte
+		tamper      func(string) string
+		expectedErr error
+	}{
+		{
+			name:   "it open v2.local token",
+			sealer: local{key: key},
+			opener: local{key: key},
+		},
+		{
+			name:   "it open v2.local token with footer",
+			sealer: local{key: key},
+			opener: local{key: key},
+			footer: []byte("kid"),
+		},
+		{
+			name:        "it reject v2.local token sealed using another key",
+			sealer:      local{key: otherKey},
+			opener:      local{key: key},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "it reject tampered v2.local token",
+			sealer:      local{key: key},
+			opener:      local{key: key},
+			tamper:      flipLastByte,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:   "it open v2.public token",
+			sealer: public{priv: priv},
+			opener: public{pub: pub},
+		},
+		{
+			name:   "it open v2.public token with footer",
+			sealer: public{priv: priv},
+			opener: public{pub: pub},
+			footer: []byte("kid"),
+		},
+		{
+			name:        "it reject v2.public token signed using another key",
+			sealer:      public{priv: priv},
+			opener:      public{pub: otherPub},
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "it reject tampered v2.public token",
+			sealer:      public{priv: priv},
+			opener:      public{pub: pub},
+			tamper:      flipLastByte,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "it reject v2.local token by v2.public protocol",
+			sealer:      local{key: key},
+			opener:      public{pub: pub},
+			expectedErr: ErrInvalidToken,
+		},
+		{
+			name:        "it reject v2.public token by v2.local protocol",
+			sealer:      public{priv: priv},
+			opener:      local{key: key},
+			expectedErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.sealer.seal(msg, tt.footer)
+			assert.NoError(t, err)
+
+			if tt.tamper != nil {
+				token = tt.tamper(token)
+			}
+
+			got, err := tt.opener.open(token)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, msg, got)
+			}
+		})
+	}
+}
+
+// flipLastByte flips token body last character.
+func flipLastByte(token string) string {
+	b := []byte(token)
+	if b[len(b)-1] == 'A' {
+		b[len(b)-1] = 'B'
+	} else {
+		b[len(b)-1] = 'A'
+	}
+	return string(b)
+}
@@ -0,0 +1,146 @@
+package paseto
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+)
+
+// registered represents paseto registered claims,
+// where time claims encoded as ISO 8601 as required by paseto spec.
+type registered struct {
+	Scope     claims.StringOrList `json:"scope,omitempty"`
+	Audience  string              `json:"aud,omitempty"`
+	ExpiresAt *time.Time          `json:"exp,omitempty"`
+	IssuedAt  *time.Time          `json:"iat,omitempty"`
+	NotBefore *time.Time          `json:"nbf,omitempty"`
+	Subject   string              `json:"sub,omitempty"`
+	Issuer    string              `json:"iss,omitempty"`
+	TokenID   string              `json:"jti,omitempty"`
+}
+
+func (r registered) standard() claims.Standard {
+	c := claims.Standard{
+		Scope:     r.Scope,
+		Audience:  claims.StringOrList{r.Audience},
+		ExpiresAt: (*claims.Time)(r.ExpiresAt),
+		IssuedAt:  (*claims.Time)(r.IssuedAt),
+		NotBefore: (*claims.Time)(r.NotBefore),
+		Subject:   r.Subject,
+		Issuer:    r.Issuer,
+		JWTID:     r.TokenID,
+	}
+	return c
+}
+
+type accessToken struct {
+	protocol protocol
+	dur      time.Duration
+	aud      string
+	iss      string
+	scp      []string
+}
+
+func (at accessToken) issue(info auth.Info) (string, error) {
+	fail := func(err error) (string, error) {
+		return "", fmt.Errorf("strategies/paseto: %w", err)
+	}
+
+	now := time.Now().UTC().Add(-claims.DefaultLeeway)
+	exp := now.Add(at.dur)
+
+	r := registered{
+		Subject:   info.GetID(),
+		Issuer:    at.iss,
+		Audience:  at.aud,
+		ExpiresAt: &exp,
+		IssuedAt:  &now,
+		NotBefore: &now,
+		Scope:     at.scp,
+	}
+
+	msg, err := merge(info, r)
+	if err != nil {
+		return fail(err)
+	}
+
+	return at.protocol.seal(msg, nil)
+}
+
+func (at accessToken) parse(tstr string) (claims.Standard, auth.Info, error) {
+	fail := func(err error) (claims.Standard, auth.Info, error) {
+		return claims.Standard{}, nil, fmt.Errorf("strategies/paseto: %w", err)
+	}
+
+	msg, err := at.protocol.open(tstr)
+	if err != nil {
+		return claims.Standard{}, nil, err
+	}
+
+	info := auth.NewUserInfo("", "", nil, make(auth.Extensions))
+	r := registered{}
+
+	if err := json.Unmarshal(msg, &r); err != nil {
+		return fail(err)
+	}
+
+	if err := json.Unmarshal(msg, info); err != nil {
+		return fail(err)
+	}
+
+	if r.ExpiresAt == nil {
+		return fail(fmt.Errorf("Token missing exp claim"))
+	}
+
+	c := r.standard()
+	opts := claims.VerifyOptions{
+		Audience: claims.StringOrList{at.aud},
+		Issuer:   at.iss,
+		Time: func() (t time.Time) {
+			return time.Now().UTC().Add(-claims.DefaultLeeway)
+		},
+	}
+
+	if err := c.Verify(opts); err != nil {
+		return fail(err)
+	}
+
+	return c, info, nil
+}
+
+// merge the given values json representation into a single json object,
+// latest values overrides previous ones.
+func merge(values ...interface{}) ([]byte, error) {
+	out := make(map[string]json.RawMessage)
+
+	for _, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		m := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, err
+		}
+
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+func newAccessToken(p protocol, opts ...auth.Option) *accessToken {
+	t := new(accessToken)
+	t.protocol = p
+	t.dur = time.Minute * 5
+	for _, opt := range opts {
+		opt.Apply(t)
+	}
+	return t
+}
@@ -0,0 +1,29 @@
+package session_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/session"
+)
+
+func Example() {
+	s := session.New(libcache.LRU.New(0))
+
+	// typically called by the login handler after verifying user credentials.
+	w := httptest.NewRecorder()
+	_, _ = s.CreateSession(w, auth.NewDefaultUser("admin", "1", nil, nil))
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.AddCookie(w.Result().Cookies()[0])
+	info, err := s.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName(), err)
+
+	// Output:
+	// admin <nil>
+}
@@ -0,0 +1,27 @@
+package session
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetCookieName sets the cookie name where session id stored.
+// Default Value DefaultCookieName.
+func SetCookieName(name string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*Session); ok {
+			v.cookie = name
+		}
+	})
+}
+
+// SetTTL sets the session lifetime, and the session cookie max age.
+// Default Value 24 Hours.
+func SetTTL(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*Session); ok {
+			v.ttl = d
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCookieName(t *testing.T) {
+	s := new(Session)
+	opt := SetCookieName("sid")
+	opt.Apply(s)
+	assert.Equal(t, "sid", s.cookie)
+}
+
+func TestSetTTL(t *testing.T) {
+	s := new(Session)
+	opt := SetTTL(time.Hour)
+	opt.Apply(s)
+	assert.Equal(t, time.Hour, s.ttl)
+}
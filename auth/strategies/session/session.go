@@ -0,0 +1,115 @@
+// Package session provides authentication strategy,
+// to authenticate HTTP requests based on server-side sessions,
+// where the session id sent by the client in a cookie.
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+// DefaultCookieName is the default cookie name where session id stored.
+const DefaultCookieName = "session_id"
+
+var (
+	// ErrMissingSession is returned by Authenticate Strategy method,
+	// when the request missing the session cookie.
+	ErrMissingSession = errors.New("strategies/session: Request missing session cookie")
+
+	// ErrInvalidSession is returned by Authenticate Strategy method,
+	// when the session does not exist or expired.
+	ErrInvalidSession = errors.New("strategies/session: Invalid session")
+)
+
+// Session authenticate requests using the session id cookie,
+// and the user info stored in the cache by CreateSession.
+type Session struct {
+	cache  auth.Cache
+	cookie string
+	ttl    time.Duration
+}
+
+// Authenticate request by looking up the session id cookie in the cache.
+func (s *Session) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	id, err := internal.ParseCookie(s.cookie, r, ErrMissingSession)
+	if err != nil {
+		return nil, ErrMissingSession
+	}
+
+	v, ok := s.cache.Load(id)
+	if !ok {
+		return nil, ErrInvalidSession
+	}
+
+	info, ok := v.(auth.Info)
+	if !ok {
+		return nil, auth.NewTypeError("strategies/session:", (*auth.Info)(nil), v)
+	}
+
+	return info, nil
+}
+
+// CreateSession generate a cryptographically random session id,
+// stores the info in the cache, and sets the session cookie,
+// with HttpOnly, Secure and SameSite=Lax attributes.
+func (s *Session) CreateSession(w http.ResponseWriter, info auth.Info) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("strategies/session: %w", err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(b)
+	s.cache.StoreWithTTL(id, info, s.ttl)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.cookie,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   int(s.ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return id, nil
+}
+
+// DestroySession deletes the request session from the cache and expires the session cookie.
+func (s *Session) DestroySession(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(s.cookie); err == nil {
+		s.cache.Delete(c.Value)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.cookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// New return new Session strategy, that stores sessions in the provided cache.
+func New(c auth.Cache, opts ...auth.Option) *Session {
+	s := new(Session)
+	s.cache = c
+	s.cookie = DefaultCookieName
+	s.ttl = time.Hour * 24
+
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+
+	return s
+}
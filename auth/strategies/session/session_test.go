@@ -0,0 +1,98 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestSession(t *testing.T) {
+	s := New(libcache.LRU.New(0), SetCookieName("sid"))
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+
+	// Round #1 create session and set secure cookie.
+	w := httptest.NewRecorder()
+	id, err := s.CreateSession(w, info)
+	assert.NoError(t, err)
+	assert.Len(t, id, 43)
+
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	c := cookies[0]
+	assert.Equal(t, "sid", c.Name)
+	assert.Equal(t, id, c.Value)
+	assert.True(t, c.HttpOnly)
+	assert.True(t, c.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, c.SameSite)
+	assert.Equal(t, 86400, c.MaxAge)
+
+	// Round #2 authenticate request using the session cookie.
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.AddCookie(c)
+	got, err := s.Authenticate(r.Context(), r)
+	assert.NoError(t, err)
+	assert.Equal(t, info, got)
+
+	// Round #3 destroy session and expire the cookie.
+	w = httptest.NewRecorder()
+	s.DestroySession(w, r)
+	expired := w.Result().Cookies()[0]
+	assert.Equal(t, "sid", expired.Name)
+	assert.Equal(t, -1, expired.MaxAge)
+
+	_, err = s.Authenticate(r.Context(), r)
+	assert.Equal(t, ErrInvalidSession, err)
+}
+
+func TestSessionAuthenticate(t *testing.T) {
+	cache := libcache.LRU.New(0)
+	cache.Store("invalid-type", "str")
+	s := New(cache)
+
+	table := []struct {
+		name        string
+		cookie      *http.Cookie
+		expectedErr string
+	}{
+		{
+			name:        "it return error when cookie missing",
+			expectedErr: ErrMissingSession.Error(),
+		},
+		{
+			name:        "it return error when session does not exist",
+			cookie:      &http.Cookie{Name: DefaultCookieName, Value: "unknown"},
+			expectedErr: ErrInvalidSession.Error(),
+		},
+		{
+			name:        "it return error when session info invalid type",
+			cookie:      &http.Cookie{Name: DefaultCookieName, Value: "invalid-type"},
+			expectedErr: "strategies/session: Invalid type",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			if tt.cookie != nil {
+				r.AddCookie(tt.cookie)
+			}
+			info, err := s.Authenticate(r.Context(), r)
+			assert.Nil(t, info)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestCreateSessionUnique(t *testing.T) {
+	s := New(libcache.LRU.New(0))
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+	a, _ := s.CreateSession(httptest.NewRecorder(), info)
+	b, _ := s.CreateSession(httptest.NewRecorder(), info)
+	assert.NotEqual(t, a, b)
+}
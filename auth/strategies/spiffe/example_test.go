@@ -0,0 +1,33 @@
+package spiffe_test
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth/strategies/spiffe"
+)
+
+func ExampleParseID() {
+	id, err := spiffe.ParseID("spiffe://example.org/ns/default/sa/api")
+	fmt.Println(id.Host, id.Path, err)
+
+	_, err = spiffe.ParseID("https://example.org/ns/default/sa/api")
+	fmt.Println(err)
+	// Output:
+	// example.org /ns/default/sa/api <nil>
+	// strategies/spiffe: Invalid SPIFFE ID
+}
+
+func ExampleNewX509() {
+	// roots holds the trust domain X.509 bundle.
+	roots := x509.NewCertPool()
+	strategy := spiffe.NewX509("example.org", roots)
+
+	// request does not present a client X.509-SVID.
+	r, _ := http.NewRequest("GET", "/", nil)
+	_, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(err != nil)
+	// Output:
+	// true
+}
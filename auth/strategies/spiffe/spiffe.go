@@ -0,0 +1,154 @@
+// Package spiffe provides authentication strategies,
+// to authenticate workloads based on SPIFFE verifiable identity documents (SVID),
+// either X.509-SVID presented through mTLS or JWT-SVID presented as a bearer token.
+// The authenticated user info name and id set to the workload SPIFFE ID.
+package spiffe
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/oauth2"
+	oauth2jwt "github.com/shaj13/go-guardian/v2/auth/strategies/oauth2/jwt"
+	x509strategy "github.com/shaj13/go-guardian/v2/auth/strategies/x509"
+)
+
+// TrustDomainExtension is the user info extension key holding the workload trust domain.
+const TrustDomainExtension = "spiffe.trust_domain"
+
+var (
+	// ErrInvalidID is returned by ParseID,
+	// when the id is not a valid SPIFFE ID.
+	ErrInvalidID = errors.New("strategies/spiffe: Invalid SPIFFE ID")
+
+	// ErrInvalidSVID is returned by Authenticate Strategy method,
+	// when the X.509-SVID does not have exactly one URI SAN.
+	ErrInvalidSVID = errors.New("strategies/spiffe: Invalid SVID, must have exactly one URI SAN")
+)
+
+// ParseID parses and validates a SPIFFE ID e.g "spiffe://example.org/workload",
+// as defined in the SPIFFE ID specification.
+func ParseID(id string) (*url.URL, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+
+	switch {
+	case u.Scheme != "spiffe",
+		len(u.Host) == 0,
+		len(u.Port()) > 0,
+		u.User != nil,
+		len(u.RawQuery) > 0,
+		len(u.Fragment) > 0,
+		u.Host != strings.ToLower(u.Host):
+		return nil, ErrInvalidID
+	}
+
+	return u, nil
+}
+
+// verifyID parses the id and verify it belongs to the trust domain.
+func verifyID(id, trustDomain string) (*url.URL, error) {
+	u, err := ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host != trustDomain {
+		return nil, fmt.Errorf("strategies/spiffe: SPIFFE ID %s is not a member of trust domain %s", id, trustDomain)
+	}
+
+	return u, nil
+}
+
+func newInfo(id *url.URL) auth.Info {
+	return auth.NewUserInfo(id.String(), id.String(), nil, auth.Extensions{
+		TrustDomainExtension: {id.Host},
+	})
+}
+
+// NewX509 return strategy authenticate request from X.509-SVID client certificate,
+// verified against the trust domain bundle roots.
+// The opts passed to the x509 strategy, to override e.g the info builder.
+func NewX509(trustDomain string, roots *x509.CertPool, opts ...auth.Option) auth.Strategy {
+	builder := func(chain [][]*x509.Certificate) (auth.Info, error) {
+		leaf := chain[0][0]
+		if len(leaf.URIs) != 1 {
+			return nil, ErrInvalidSVID
+		}
+
+		id, err := verifyID(leaf.URIs[0].String(), trustDomain)
+		if err != nil {
+			return nil, err
+		}
+
+		return newInfo(id), nil
+	}
+
+	vopts := x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	opts = append([]auth.Option{
+		x509strategy.SetAllowEmptyCN(),
+		x509strategy.SetInfoBuilder(builder),
+	}, opts...)
+
+	return x509strategy.New(vopts, opts...)
+}
+
+// svidClaims represents JWT-SVID claims.
+type svidClaims struct {
+	oauth2jwt.Claims
+	trustDomain string
+	id          *url.URL
+}
+
+func (s *svidClaims) New() oauth2.ClaimsResolver {
+	c := s.Claims.New().(*oauth2jwt.Claims)
+	return &svidClaims{Claims: *c, trustDomain: s.trustDomain}
+}
+
+func (s *svidClaims) Verify(opts claims.VerifyOptions) error {
+	if s.ExpiresAt == nil || len(s.Audience) == 0 {
+		return errors.New("strategies/spiffe: JWT-SVID missing exp or aud claim")
+	}
+
+	if err := s.Standard.Verify(opts); err != nil {
+		return err
+	}
+
+	id, err := verifyID(s.Subject, s.trustDomain)
+	if err != nil {
+		return err
+	}
+
+	s.id = id
+	return nil
+}
+
+func (s *svidClaims) Resolve() auth.Info {
+	return newInfo(s.id)
+}
+
+// NewJWT return strategy authenticate request from JWT-SVID bearer token,
+// signed by a key of the trust domain JWKS bundle served at bundleAddr,
+// and intended for the audience.
+// The opts passed to the oauth2 jwt strategy, to configure e.g the bundle http client.
+func NewJWT(trustDomain, bundleAddr, audience string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	opts = append([]auth.Option{
+		oauth2jwt.SetClaimResolver(&svidClaims{trustDomain: trustDomain}),
+		oauth2jwt.SetVerifyOptions(claims.VerifyOptions{
+			Audience: []string{audience},
+		}),
+	}, opts...)
+
+	return oauth2jwt.New(bundleAddr, c, opts...)
+}
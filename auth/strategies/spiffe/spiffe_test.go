@@ -0,0 +1,284 @@
+package spiffe
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/internal/jwt"
+)
+
+func TestParseID(t *testing.T) {
+	table := []struct {
+		id    string
+		valid bool
+	}{
+		{id: "spiffe://example.org/workload", valid: true},
+		{id: "spiffe://example.org", valid: true},
+		{id: "https://example.org/workload"},
+		{id: "spiffe:///workload"},
+		{id: "spiffe://example.org:8080/workload"},
+		{id: "spiffe://user@example.org/workload"},
+		{id: "spiffe://example.org/workload?q=1"},
+		{id: "spiffe://example.org/workload#f"},
+		{id: "spiffe://Example.org/workload"},
+		{id: "%"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.id, func(t *testing.T) {
+			u, err := ParseID(tt.id)
+			if !tt.valid {
+				assert.Equal(t, ErrInvalidID, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "example.org", u.Host)
+		})
+	}
+}
+
+func TestX509(t *testing.T) {
+	ca, caKey := generateCert(t, nil, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	table := []struct {
+		name        string
+		uris        []string
+		expectedErr bool
+	}{
+		{
+			name: "it return user info when svid valid",
+			uris: []string{"spiffe://example.org/workload"},
+		},
+		{
+			name:        "it return error when svid does not have uri san",
+			expectedErr: true,
+		},
+		{
+			name:        "it return error when svid has multiple uri san",
+			uris:        []string{"spiffe://example.org/a", "spiffe://example.org/b"},
+			expectedErr: true,
+		},
+		{
+			name:        "it return error when svid belongs to another trust domain",
+			uris:        []string{"spiffe://other.org/workload"},
+			expectedErr: true,
+		},
+		{
+			name:        "it return error when uri san is not spiffe id",
+			uris:        []string{"https://example.org/workload"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			leaf, _ := generateCert(t, ca, caKey, tt.uris...)
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}
+
+			info, err := NewX509("example.org", roots).Authenticate(r.Context(), r)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.uris[0], info.GetUserName())
+			assert.Equal(t, tt.uris[0], info.GetID())
+			assert.Equal(t, "example.org", info.GetExtensions().Get(TrustDomainExtension))
+		})
+	}
+}
+
+func TestJWT(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	srv := mockBundleServer(t, key)
+	defer srv.Close()
+
+	exp := claims.Time(time.Now().Add(time.Hour))
+	expired := claims.Time(time.Now().Add(-time.Hour))
+
+	table := []struct {
+		name        string
+		claims      claims.Standard
+		expectedErr string
+	}{
+		{
+			name: "it return user info when svid valid",
+			claims: claims.Standard{
+				Subject:   "spiffe://example.org/workload",
+				Audience:  claims.StringOrList{"api"},
+				ExpiresAt: &exp,
+			},
+		},
+		{
+			name: "it return error when svid expired",
+			claims: claims.Standard{
+				Subject:   "spiffe://example.org/workload",
+				Audience:  claims.StringOrList{"api"},
+				ExpiresAt: &expired,
+			},
+			expectedErr: "expired",
+		},
+		{
+			name: "it return error when svid missing exp",
+			claims: claims.Standard{
+				Subject:  "spiffe://example.org/workload",
+				Audience: claims.StringOrList{"api"},
+			},
+			expectedErr: "missing exp or aud",
+		},
+		{
+			name: "it return error when svid intended for another audience",
+			claims: claims.Standard{
+				Subject:   "spiffe://example.org/workload",
+				Audience:  claims.StringOrList{"db"},
+				ExpiresAt: &exp,
+			},
+			expectedErr: "audience",
+		},
+		{
+			name: "it return error when svid belongs to another trust domain",
+			claims: claims.Standard{
+				Subject:   "spiffe://other.org/workload",
+				Audience:  claims.StringOrList{"api"},
+				ExpiresAt: &exp,
+			},
+			expectedErr: "not a member of trust domain",
+		},
+		{
+			name: "it return error when svid subject is not spiffe id",
+			claims: claims.Standard{
+				Subject:   "workload",
+				Audience:  claims.StringOrList{"api"},
+				ExpiresAt: &exp,
+			},
+			expectedErr: ErrInvalidID.Error(),
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := jwt.IssueToken(rsaKeeper{key}, tt.claims)
+			assert.NoError(t, err)
+
+			s := NewJWT("example.org", srv.URL, "api", libcache.LRU.New(0))
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+tk)
+
+			info, err := s.Authenticate(context.TODO(), r)
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.claims.Subject, info.GetUserName())
+			assert.Equal(t, "example.org", info.GetExtensions().Get(TrustDomainExtension))
+		})
+	}
+}
+
+type rsaKeeper struct {
+	key *rsa.PrivateKey
+}
+
+func (r rsaKeeper) KID() string {
+	return "spiffe"
+}
+
+func (r rsaKeeper) Get(string) (interface{}, string, error) {
+	return r.key, "RS256", nil
+}
+
+func mockBundleServer(tb testing.TB, key *rsa.PrivateKey) *httptest.Server {
+	kset := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{
+				Key:       key.Public(),
+				KeyID:     "spiffe",
+				Algorithm: "RS256",
+				Use:       "jwt-svid",
+			},
+		},
+	}
+
+	body, err := json.Marshal(kset)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(h))
+}
+
+// generateCert generate a X.509-SVID signed by parent with the given uri sans,
+// or a self-signed ca certificate when parent is nil.
+func generateCert(tb testing.TB, parent *x509.Certificate, parentKey crypto.Signer, uris ...string) (*x509.Certificate, crypto.Signer) { //nolint:lll
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, v := range uris {
+		u, err := url.Parse(v)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		tmpl.URIs = append(tmpl.URIs, u)
+	}
+
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return cert, key
+}
@@ -0,0 +1,47 @@
+package tenant_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/tenant"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+func Example() {
+	shared := libcache.LRU.New(0)
+
+	validate := func(ctx context.Context, r *http.Request, tk string) (auth.Info, time.Time, error) {
+		// typically validated against the tenant identity provider.
+		if tk != "acme-token" {
+			return nil, time.Time{}, fmt.Errorf("invalid token")
+		}
+		return auth.NewDefaultUser("alice", "1", nil, nil), time.Now().Add(time.Hour), nil
+	}
+
+	strategy := tenant.New(tenant.HeaderResolver("X-Tenant"), map[string]auth.Strategy{
+		"acme":   token.New(validate, tenant.Cache(shared, "acme")),
+		"globex": token.New(token.NoOpAuthenticate, tenant.Cache(shared, "globex")),
+	})
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer acme-token")
+
+	r.Header.Set("X-Tenant", "acme")
+	info, _ := strategy.Authenticate(r.Context(), r)
+	fmt.Println(info.GetUserName())
+
+	r.Header.Set("X-Tenant", "globex")
+	_, err := strategy.Authenticate(r.Context(), r)
+	fmt.Println(err != nil)
+
+	// Output:
+	// alice
+	// true
+}
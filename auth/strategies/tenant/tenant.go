@@ -0,0 +1,103 @@
+// Package tenant provides authentication strategy,
+// to authenticate HTTP requests of multi-tenant applications,
+// where each tenant has its own strategy and isolated cache,
+// hence a tenant token never authenticate a user of another tenant.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+var (
+	// ErrMissingTenant is returned by HeaderResolver,
+	// when the request missing the tenant header.
+	ErrMissingTenant = errors.New("strategies/tenant: Request missing tenant")
+
+	// ErrUnknownTenant is returned by Authenticate Strategy method,
+	// when the resolved tenant has no registered strategy.
+	ErrUnknownTenant = errors.New("strategies/tenant: Unknown tenant")
+)
+
+// Resolver declare function signature to resolve the request tenant id.
+type Resolver func(r *http.Request) (tenantID string, err error)
+
+// HeaderResolver return Resolver, where tenant id extracted from the given header.
+func HeaderResolver(header string) Resolver {
+	return func(r *http.Request) (string, error) {
+		return internal.ParseHeader(header, r, ErrMissingTenant)
+	}
+}
+
+type multiTenant struct {
+	resolver   Resolver
+	strategies map[string]auth.Strategy
+}
+
+func (m multiTenant) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	id, err := m.resolver(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := m.strategies[id]
+	if !ok {
+		return nil, ErrUnknownTenant
+	}
+
+	return s.Authenticate(ctx, r)
+}
+
+// New return strategy, that resolve the request tenant and authenticate the request,
+// using the tenant strategy.
+// Each tenant strategy must use its own cache or a cache scoped by Cache,
+// to prevent cache entries from bleeding between tenants.
+func New(resolver Resolver, strategies map[string]auth.Strategy) auth.Strategy {
+	m := multiTenant{
+		resolver:   resolver,
+		strategies: make(map[string]auth.Strategy, len(strategies)),
+	}
+
+	for id, s := range strategies {
+		m.strategies[id] = s
+	}
+
+	return m
+}
+
+type key struct {
+	tenant string
+	key    interface{}
+}
+
+type cache struct {
+	auth.Cache
+	tenant string
+}
+
+func (c cache) Load(k interface{}) (interface{}, bool) {
+	return c.Cache.Load(key{c.tenant, k})
+}
+
+func (c cache) Store(k interface{}, v interface{}) {
+	c.Cache.Store(key{c.tenant, k}, v)
+}
+
+func (c cache) StoreWithTTL(k interface{}, v interface{}, ttl time.Duration) {
+	c.Cache.StoreWithTTL(key{c.tenant, k}, v, ttl)
+}
+
+func (c cache) Delete(k interface{}) {
+	c.Cache.Delete(key{c.tenant, k})
+}
+
+// Cache return cache, that scopes the keys of the shared cache to the tenant id,
+// so a single cache can be shared between the tenants strategies.
+func Cache(c auth.Cache, tenantID string) auth.Cache {
+	return cache{Cache: c, tenant: tenantID}
+}
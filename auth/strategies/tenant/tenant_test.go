@@ -0,0 +1,98 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+func tenantStrategy(c auth.Cache, id string, calls *int) auth.Strategy {
+	fn := func(ctx context.Context, r *http.Request, tk string) (auth.Info, time.Time, error) {
+		*calls++
+		if tk != id+"-token" {
+			return nil, time.Time{}, errInvalidToken
+		}
+		return auth.NewDefaultUser(id+"-user", "1", nil, nil), time.Now().Add(time.Hour), nil
+	}
+	return token.New(fn, Cache(c, id))
+}
+
+func TestMultiTenant(t *testing.T) {
+	shared := libcache.LRU.New(0)
+	var callsA, callsB int
+
+	s := New(HeaderResolver("X-Tenant"), map[string]auth.Strategy{
+		"a": tenantStrategy(shared, "a", &callsA),
+		"b": tenantStrategy(shared, "b", &callsB),
+	})
+
+	authenticate := func(tenant, tk string) (auth.Info, error) {
+		r, _ := http.NewRequest("GET", "/", nil)
+		if len(tenant) > 0 {
+			r.Header.Set("X-Tenant", tenant)
+		}
+		r.Header.Set("Authorization", "Bearer "+tk)
+		return s.Authenticate(r.Context(), r)
+	}
+
+	// Round #1 tenant A token authenticate on tenant A.
+	info, err := authenticate("a", "a-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "a-user", info.GetUserName())
+	assert.Equal(t, 1, callsA)
+
+	// Round #2 cached for tenant A.
+	_, err = authenticate("a", "a-token")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, callsA)
+
+	// Round #3 tenant A token rejected on tenant B, cache entry does not bleed.
+	_, err = authenticate("b", "a-token")
+	assert.Equal(t, errInvalidToken, err)
+	assert.Equal(t, 1, callsB)
+
+	_, ok := shared.Load(key{"b", "a-token"})
+	assert.False(t, ok)
+	_, ok = shared.Load(key{"a", "a-token"})
+	assert.True(t, ok)
+	_, ok = shared.Load("a-token")
+	assert.False(t, ok)
+
+	// Round #4 unknown and missing tenant.
+	_, err = authenticate("c", "a-token")
+	assert.Equal(t, ErrUnknownTenant, err)
+
+	_, err = authenticate("", "a-token")
+	assert.Equal(t, ErrMissingTenant, err)
+}
+
+func TestCache(t *testing.T) {
+	shared := libcache.LRU.New(0)
+	a := Cache(shared, "a")
+	b := Cache(shared, "b")
+
+	a.Store("key", "a-value")
+	b.StoreWithTTL("key", "b-value", time.Hour)
+
+	v, _ := a.Load("key")
+	assert.Equal(t, "a-value", v)
+	v, _ = b.Load("key")
+	assert.Equal(t, "b-value", v)
+
+	a.Delete("key")
+	_, ok := a.Load("key")
+	assert.False(t, ok)
+	_, ok = b.Load("key")
+	assert.True(t, ok)
+}
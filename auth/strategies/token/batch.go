@@ -0,0 +1,61 @@
+package token
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// BatchResult represents a single token authentication result.
+type BatchResult struct {
+	Info auth.Info
+	Err  error
+}
+
+// BatchAuthenticator is implemented by token strategies,
+// to authenticate a batch of tokens at once, e.g webhooks.
+type BatchAuthenticator interface {
+	// AuthenticateBatch authenticate the tokens in parallel and return results in input order.
+	// A token failure recorded in its result and does not abort the batch,
+	// the returned error is non-nil only when the request context is done.
+	AuthenticateBatch(tokens []string, r *http.Request) ([]BatchResult, error)
+}
+
+func (c *core) AuthenticateBatch(tokens []string, r *http.Request) ([]BatchResult, error) {
+	ctx := r.Context()
+	results := make([]BatchResult, len(tokens))
+	sem := make(chan struct{}, c.concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, token := range tokens {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, token string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			hash := c.hasher.Hash(token)
+			info, err := c.strategy.authenticate(ctx, r, hash, token)
+			if err == nil {
+				err = c.verify(ctx, r, info, token)
+			}
+
+			if err != nil {
+				c.hooks.OnAuthFailed(err, r)
+				results[i] = BatchResult{Err: err}
+				return
+			}
+
+			c.hooks.OnAuthenticated(info, r)
+			results[i] = BatchResult{Info: info}
+		}(i, token)
+	}
+
+	wg.Wait()
+
+	return results, ctx.Err()
+}
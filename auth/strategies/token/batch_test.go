@@ -0,0 +1,99 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestAuthenticateBatch(t *testing.T) {
+	var calls, inflight, peak int32
+	errInvalid := errors.New("invalid token")
+
+	fn := func(_ context.Context, _ *http.Request, token string) (auth.Info, time.Time, error) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 5)
+
+		if token == "invalid" {
+			return nil, time.Time{}, errInvalid
+		}
+		return auth.NewUserInfo(token, token, nil, nil), time.Now().Add(time.Hour), nil
+	}
+
+	cache := libcache.LRU.New(0)
+	cache.Store("cached-1", auth.NewUserInfo("cached-1", "cached-1", nil, nil))
+	cache.Store("cached-2", auth.NewUserInfo("cached-2", "cached-2", nil, nil))
+
+	s := New(fn, cache, SetBatchConcurrency(2)).(BatchAuthenticator)
+	r, _ := http.NewRequest("POST", "/webhooks", nil)
+
+	tokens := []string{"cached-1", "miss-1", "invalid", "cached-2", "miss-2", "miss-3"}
+	results, err := s.AuthenticateBatch(tokens, r)
+	assert.NoError(t, err)
+	assert.Len(t, results, len(tokens))
+
+	for i, token := range tokens {
+		if token == "invalid" {
+			assert.Equal(t, errInvalid, results[i].Err)
+			assert.Nil(t, results[i].Info)
+			continue
+		}
+		assert.NoError(t, results[i].Err)
+		assert.Equal(t, token, results[i].Info.GetUserName(), "results must be in input order")
+	}
+
+	// backend called only for cache misses.
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.True(t, atomic.LoadInt32(&peak) <= 2, "concurrency must be bounded")
+
+	// Round #2 all hits returned in order without calling the backend.
+	hits := []string{"miss-3", "cached-2", "miss-1"}
+	results, err = s.AuthenticateBatch(hits, r)
+	assert.NoError(t, err)
+	for i, token := range hits {
+		assert.Equal(t, token, results[i].Info.GetUserName())
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestAuthenticateBatchVerify(t *testing.T) {
+	cache := libcache.LRU.New(0)
+	info := auth.NewUserInfo("test", "1", nil, nil)
+	WithNamedScopes(info, "read:users")
+	cache.Store("token", info)
+
+	s := New(NoOpAuthenticate, cache, SetScopes(NewScope("write", "/", "GET"))).(BatchAuthenticator)
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	results, err := s.AuthenticateBatch([]string{"token"}, r)
+	assert.NoError(t, err)
+	assert.Error(t, results[0].Err)
+}
+
+func TestAuthenticateBatchContext(t *testing.T) {
+	s := New(NoOpAuthenticate, libcache.LRU.New(0)).(BatchAuthenticator)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r, _ := http.NewRequestWithContext(ctx, "GET", "/", nil)
+
+	results, err := s.AuthenticateBatch([]string{"a", "b"}, r)
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, results, 2)
+}
@@ -0,0 +1,126 @@
+package token
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/arc"
+	_ "github.com/shaj13/libcache/fifo"
+	_ "github.com/shaj13/libcache/lfu"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+var benchPolicies = []struct {
+	name   string
+	policy libcache.ReplacementPolicy
+}{
+	{"LRU", libcache.LRU},
+	{"FIFO", libcache.FIFO},
+	{"LFU", libcache.LFU},
+	{"ARC", libcache.ARC},
+}
+
+// BenchmarkCachePolicies compare the cache replacement policies behind the cached token strategy,
+// at different parallelism levels, to guide the cache and capacity choice, e.g
+//
+// 		go test -run=^$ -bench=CachePolicies -count=10 | benchstat
+//
+func BenchmarkCachePolicies(b *testing.B) {
+	const capacity = 1000
+
+	for _, p := range benchPolicies {
+		for _, parallelism := range []int{1, 8, 32} {
+			suffix := "/parallelism-" + strconv.Itoa(parallelism)
+
+			b.Run(p.name+"/Store"+suffix, func(b *testing.B) {
+				s := New(NoOpAuthenticate, p.policy.New(capacity))
+				info := auth.NewDefaultUser("benchmark", "1", nil, nil)
+				var n uint64
+
+				b.SetParallelism(parallelism)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						key := strconv.FormatUint(atomic.AddUint64(&n, 1), 10)
+						_ = auth.Append(s, key, info)
+					}
+				})
+			})
+
+			b.Run(p.name+"/Load_Hit"+suffix, func(b *testing.B) {
+				cache := p.policy.New(capacity)
+				s := New(NoOpAuthenticate, cache)
+				for i := 0; i < capacity; i++ {
+					cache.Store(strconv.Itoa(i), auth.NewDefaultUser("benchmark", "1", nil, nil))
+				}
+
+				benchmarkAuthenticate(b, s, parallelism, func(r *rand.Rand) func() string {
+					return func() string { return strconv.Itoa(r.Intn(capacity)) }
+				})
+			})
+
+			b.Run(p.name+"/Load_Miss"+suffix, func(b *testing.B) {
+				s := New(benchAuthenticate, p.policy.New(capacity))
+				var n uint64
+
+				benchmarkAuthenticate(b, s, parallelism, func(*rand.Rand) func() string {
+					return func() string { return "miss-" + strconv.FormatUint(atomic.AddUint64(&n, 1), 10) }
+				})
+			})
+
+			b.Run(p.name+"/Zipf"+suffix, func(b *testing.B) {
+				var calls, total uint64
+				fn := func(ctx context.Context, r *http.Request, token string) (auth.Info, time.Time, error) {
+					atomic.AddUint64(&calls, 1)
+					return benchAuthenticate(ctx, r, token)
+				}
+				s := New(fn, p.policy.New(capacity))
+
+				// skewed traffic over a key space 10 times larger than the cache capacity.
+				benchmarkAuthenticate(b, s, parallelism, func(r *rand.Rand) func() string {
+					z := rand.NewZipf(r, 1.1, 1, capacity*10)
+					return func() string {
+						atomic.AddUint64(&total, 1)
+						return strconv.FormatUint(z.Uint64(), 10)
+					}
+				})
+
+				if total > 0 {
+					b.ReportMetric(1-float64(calls)/float64(total), "hit_rate")
+				}
+			})
+		}
+	}
+}
+
+// benchmarkAuthenticate authenticate requests in parallel,
+// newKey called once per goroutine to return its token generator.
+func benchmarkAuthenticate(b *testing.B, s auth.Strategy, parallelism int, newKey func(*rand.Rand) func() string) {
+	var seed int64
+
+	b.SetParallelism(parallelism)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		key := newKey(rand.New(rand.NewSource(atomic.AddInt64(&seed, 1))))
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		for pb.Next() {
+			r.Header.Set("Authorization", "Bearer "+key())
+			if _, err := s.Authenticate(r.Context(), r); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func benchAuthenticate(_ context.Context, _ *http.Request, token string) (auth.Info, time.Time, error) {
+	return auth.NewDefaultUser(token, "1", nil, nil), time.Now().Add(time.Hour), nil
+}
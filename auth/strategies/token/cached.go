@@ -2,6 +2,8 @@ package token
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"time"
 
@@ -25,16 +27,31 @@ func New(fn AuthenticateFunc, ac auth.Cache, opts ...auth.Option) auth.Strategy
 	c := new(cachedToken)
 	c.cache = ac
 	c.fn = fn
+	for _, opt := range opts {
+		opt.Apply(c)
+	}
 	return newCore(c, opts...)
 }
 
+// negative represents a cached authentication failure.
+type negative struct {
+	err error
+}
+
 type cachedToken struct {
-	cache auth.Cache
-	fn    AuthenticateFunc
+	cache       auth.Cache
+	fn          AuthenticateFunc
+	negativeTTL time.Duration
+	hooks       auth.EventHooks
 }
 
 func (c *cachedToken) authenticate(ctx context.Context, r *http.Request, hash, token string) (auth.Info, error) {
 	if v, ok := c.cache.Load(hash); ok {
+		c.hooks.OnCacheHit(fingerprint(hash))
+		if n, ok := v.(negative); ok {
+			return nil, n.err
+		}
+
 		info, ok := v.(auth.Info)
 		if !ok {
 			return nil, auth.NewTypeError("strategies/token:", (*auth.Info)(nil), v)
@@ -43,15 +60,34 @@ func (c *cachedToken) authenticate(ctx context.Context, r *http.Request, hash, t
 	}
 
 	// token not found invoke user authenticate function
+	c.hooks.OnCacheMiss(fingerprint(hash))
 	info, t, err := c.fn(ctx, r, token)
 	if err != nil {
+		if c.negativeTTL > 0 {
+			c.cache.StoreWithTTL(hash, negative{err: err}, c.negativeTTL)
+		}
 		return nil, err
 	}
 
-	c.cache.StoreWithTTL(hash, info, time.Until(t))
+	// the cache treats a non-positive ttl as no expiry,
+	// so a token already past its expiry (e.g accepted within a leeway) must not be cached.
+	ttl := time.Until(t)
+	if !t.IsZero() && ttl <= 0 {
+		return info, nil
+	}
+
+	c.cache.StoreWithTTL(hash, info, ttl)
 	return info, nil
 }
 
+// fingerprint return the first 16 hex characters of the key sha256,
+// passed to the event hooks instead of the cache key,
+// which is the raw token unless SetHash used.
+func fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
 func (c *cachedToken) append(token string, info auth.Info) error {
 	c.cache.Store(token, info)
 	return nil
@@ -0,0 +1,94 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// TestCachedTokenConcurrency exercise the cached token strategy and its bounded cache,
+// from thousands of goroutines, it's meant to be run with the race detector.
+func TestCachedTokenConcurrency(t *testing.T) {
+	t.Parallel()
+
+	const (
+		capacity   = 64
+		tokens     = 256
+		goroutines = 2000
+		iterations = 20
+	)
+
+	fn := func(_ context.Context, _ *http.Request, tk string) (auth.Info, time.Time, error) {
+		if tk == "invalid" {
+			return nil, time.Time{}, ErrTokenNotFound
+		}
+		return auth.NewDefaultUser(tk, tk, nil, nil), time.Now().Add(time.Minute), nil
+	}
+
+	cache := libcache.LRU.New(capacity)
+	strategy := New(fn, cache, SetNegativeTTL(time.Millisecond))
+
+	wg := sync.WaitGroup{}
+	errs := make(chan string, goroutines)
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < iterations; i++ {
+				token := strconv.Itoa((g*iterations + i) % tokens)
+
+				switch (g + i) % 6 {
+				case 0:
+					r, _ := http.NewRequest("GET", "/", nil)
+					r.Header.Set("Authorization", "Bearer "+token)
+					info, err := strategy.Authenticate(r.Context(), r)
+					if err != nil || info.GetUserName() != token {
+						errs <- "authenticate " + token
+						return
+					}
+				case 1:
+					r, _ := http.NewRequest("GET", "/", nil)
+					r.Header.Set("Authorization", "Bearer invalid")
+					if _, err := strategy.Authenticate(r.Context(), r); err != ErrTokenNotFound {
+						errs <- "authenticate invalid token"
+						return
+					}
+				case 2:
+					_ = auth.Append(strategy, token, auth.NewDefaultUser(token, token, nil, nil))
+				case 3:
+					_ = auth.Revoke(strategy, token)
+				case 4:
+					_, _ = cache.Peek(token)
+					_ = cache.Keys()
+				case 5:
+					if i%10 == 0 {
+						cache.Purge()
+					}
+				}
+
+				if n := cache.Len(); n > capacity {
+					errs <- "cache length " + strconv.Itoa(n) + " exceeds capacity"
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.Fail(t, err)
+	}
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"context"
+	"crypto"
 	"net/http"
 	"testing"
 	"time"
@@ -72,6 +73,72 @@ func TestCahcedTokenAppend(t *testing.T) {
 	assert.Equal(t, info, cachedInfo)
 }
 
+func TestCahcedTokenHash(t *testing.T) {
+	token := ""
+	fn := func(_ context.Context, _ *http.Request, tk string) (auth.Info, time.Time, error) {
+		token = tk
+		return auth.NewDefaultUser("1", "1", nil, nil), time.Now().Add(time.Hour), nil
+	}
+	cache := libcache.LRU.New(0)
+	strategy := New(fn, cache, SetHash(crypto.SHA256, []byte("key")))
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer valid")
+
+	_, err := strategy.Authenticate(r.Context(), r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "valid", token)
+	assert.False(t, cache.Contains("valid"))
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestCahcedTokenNegativeTTL(t *testing.T) {
+	calls := 0
+	fn := func(_ context.Context, _ *http.Request, tk string) (auth.Info, time.Time, error) {
+		calls++
+		return nil, time.Time{}, ErrTokenNotFound
+	}
+	strategy := New(fn, libcache.LRU.New(0), SetNegativeTTL(time.Millisecond*50))
+	authenticate := func() error {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", "Bearer invalid")
+		_, err := strategy.Authenticate(r.Context(), r)
+		return err
+	}
+
+	// Round #1 first failure invoke authenticate func and cache it.
+	assert.Equal(t, ErrTokenNotFound, authenticate())
+	assert.Equal(t, 1, calls)
+
+	// Round #2 failure served from cache within negative ttl.
+	assert.Equal(t, ErrTokenNotFound, authenticate())
+	assert.Equal(t, 1, calls)
+
+	// Round #3 authenticate func invoked again after negative ttl.
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, ErrTokenNotFound, authenticate())
+	assert.Equal(t, 2, calls)
+}
+
+func TestCahcedTokenNegativeTTLDisabled(t *testing.T) {
+	calls := 0
+	fn := func(_ context.Context, _ *http.Request, tk string) (auth.Info, time.Time, error) {
+		calls++
+		return nil, time.Time{}, ErrTokenNotFound
+	}
+	cache := libcache.LRU.New(0)
+	strategy := New(fn, cache)
+
+	for i := 0; i < 2; i++ {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", "Bearer invalid")
+		_, _ = strategy.Authenticate(r.Context(), r)
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 0, cache.Len())
+}
+
 func BenchmarkCachedToken(b *testing.B) {
 	r, _ := http.NewRequest("GET", "/", nil)
 	r.Header.Set("Authorization", "Bearer token")
@@ -91,3 +158,103 @@ func BenchmarkCachedToken(b *testing.B) {
 		}
 	})
 }
+
+func TestCahcedTokenExpired(t *testing.T) {
+	calls := 0
+	fn := func(_ context.Context, _ *http.Request, _ string) (auth.Info, time.Time, error) {
+		calls++
+		return auth.NewDefaultUser("1", "1", nil, nil), time.Now().Add(-time.Second), nil
+	}
+
+	cache := libcache.LRU.New(0)
+	strategy := New(fn, cache)
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer token")
+
+	for i := 1; i <= 2; i++ {
+		_, err := strategy.Authenticate(r.Context(), r)
+		assert.NoError(t, err)
+		assert.Equal(t, i, calls)
+	}
+
+	_, ok := cache.Load("token")
+	assert.False(t, ok)
+}
+
+func TestCahcedTokenHooks(t *testing.T) {
+	events := []string{}
+	hook := &recordHook{events: &events}
+	fn := func(_ context.Context, _ *http.Request, tk string) (auth.Info, time.Time, error) {
+		if tk == "invalid" {
+			return nil, time.Time{}, ErrTokenNotFound
+		}
+		return auth.NewDefaultUser("test", "1", nil, nil), time.Now().Add(time.Hour), nil
+	}
+	strategy := New(fn, libcache.LRU.New(0), WithHooks(panicHook{}, hook))
+	authenticate := func(token string) (auth.Info, error) {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		hook.request = r
+		return strategy.Authenticate(r.Context(), r)
+	}
+
+	info, err := authenticate("valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", info.GetUserName())
+
+	info, err = authenticate("valid")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", info.GetUserName())
+
+	_, err = authenticate("invalid")
+	assert.Equal(t, ErrTokenNotFound, err)
+
+	assert.Equal(t, []string{
+		"miss:" + fingerprint("valid"),
+		"authenticated:test",
+		"hit:" + fingerprint("valid"),
+		"authenticated:test",
+		"miss:" + fingerprint("invalid"),
+		"failed:" + ErrTokenNotFound.Error(),
+	}, events)
+
+	for _, e := range events {
+		assert.NotContains(t, e, ":valid", "raw token must never reach a hook")
+		assert.NotContains(t, e, ":invalid", "raw token must never reach a hook")
+	}
+}
+
+type recordHook struct {
+	events  *[]string
+	request *http.Request
+}
+
+func (h *recordHook) OnAuthenticated(info auth.Info, r *http.Request) {
+	h.record(r, "authenticated:"+info.GetUserName())
+}
+
+func (h *recordHook) OnAuthFailed(err error, r *http.Request) {
+	h.record(r, "failed:"+err.Error())
+}
+
+func (h *recordHook) OnCacheHit(key string) {
+	*h.events = append(*h.events, "hit:"+key)
+}
+
+func (h *recordHook) OnCacheMiss(key string) {
+	*h.events = append(*h.events, "miss:"+key)
+}
+
+func (h *recordHook) record(r *http.Request, event string) {
+	if r != h.request {
+		event = "unexpected request"
+	}
+	*h.events = append(*h.events, event)
+}
+
+type panicHook struct{}
+
+func (panicHook) OnAuthenticated(auth.Info, *http.Request) { panic("boom") }
+func (panicHook) OnAuthFailed(error, *http.Request)        { panic("boom") }
+func (panicHook) OnCacheHit(string)                        { panic("boom") }
+func (panicHook) OnCacheMiss(string)                       { panic("boom") }
@@ -0,0 +1,52 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// randReader is the source of Generate randomness, replaced in tests.
+var randReader io.Reader = rand.Reader
+
+// Generate return a cryptographically secure random token, of length random bytes,
+// encoded in base62 (0-9, a-z, A-Z) to be URL-safe,
+// commonly used for session ids, CSRF and opaque access tokens.
+// The token has a fixed width of ceil(length * 8 / log2(62)) characters, left padded with zeros.
+func Generate(length int) (string, error) {
+	b, err := random(length)
+	if err != nil {
+		return "", err
+	}
+
+	width := int(math.Ceil(float64(length*8) / math.Log2(62)))
+	str := new(big.Int).SetBytes(b).Text(62)
+	return strings.Repeat("0", width-len(str)) + str, nil
+}
+
+// GenerateHex return a cryptographically secure random token,
+// of length random bytes encoded in hex.
+func GenerateHex(length int) (string, error) {
+	b, err := random(length)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func random(length int) ([]byte, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("strategies/token: Invalid token length %d", length)
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(randReader, b); err != nil {
+		return nil, fmt.Errorf("strategies/token: %w", err)
+	}
+
+	return b, nil
+}
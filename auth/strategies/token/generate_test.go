@@ -0,0 +1,97 @@
+package token
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	base62 := regexp.MustCompile(`^[0-9a-zA-Z]+$`)
+
+	for _, length := range []int{1, 16, 32, 64} {
+		str, err := Generate(length)
+		assert.NoError(t, err)
+		assert.Regexp(t, base62, str)
+
+		// base62 encoding of length bytes, padded to ceil(length * 8 / log2(62)) chars.
+		width := int(math.Ceil(float64(length*8) / math.Log2(62)))
+		assert.Len(t, str, width)
+
+		n, ok := new(big.Int).SetString(str, 62)
+		assert.True(t, ok)
+		assert.True(t, n.BitLen() <= length*8)
+
+		hex, err := GenerateHex(length)
+		assert.NoError(t, err)
+		assert.Len(t, hex, length*2)
+	}
+}
+
+func TestGenerateUnique(t *testing.T) {
+	seen := make(map[string]struct{}, 10000)
+	chars := make(map[rune]int)
+
+	for i := 0; i < 10000; i++ {
+		str, err := Generate(16)
+		assert.NoError(t, err)
+		_, ok := seen[str]
+		assert.False(t, ok, "duplicate token %s", str)
+		seen[str] = struct{}{}
+
+		for _, c := range str {
+			chars[c]++
+		}
+	}
+
+	// all base62 characters appear over 10k samples.
+	assert.Len(t, chars, 62)
+}
+
+func TestGenerateFixedWidth(t *testing.T) {
+	defer func() { randReader = rand.Reader }()
+
+	randReader = bytes.NewReader(make([]byte, 16))
+	str, err := Generate(16)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("0", 22), str)
+
+	randReader = bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))
+	str, err = Generate(16)
+	assert.NoError(t, err)
+	assert.Len(t, str, 22)
+}
+
+func TestGenerateInvalidLength(t *testing.T) {
+	_, err := Generate(0)
+	assert.Error(t, err)
+	_, err = GenerateHex(-1)
+	assert.Error(t, err)
+}
+
+func TestGenerateRandFailure(t *testing.T) {
+	errRand := errors.New("entropy exhausted")
+	randReader = failingReader{errRand}
+	defer func() { randReader = rand.Reader }()
+
+	_, err := Generate(32)
+	assert.True(t, errors.Is(err, errRand))
+
+	_, err = GenerateHex(32)
+	assert.True(t, errors.Is(err, errRand))
+}
+
+type failingReader struct {
+	err error
+}
+
+func (f failingReader) Read([]byte) (int, error) {
+	return 0, f.err
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"crypto"
+	"time"
 
 	"github.com/shaj13/go-guardian/v2/auth"
 	"github.com/shaj13/go-guardian/v2/auth/internal"
@@ -25,6 +26,34 @@ func SetParser(p Parser) auth.Option {
 	})
 }
 
+// WithHeader adds a custom header as a token extraction source.
+// Extraction sources are tried after the strategy parser,
+// in the order given, and the first non-empty value used.
+func WithHeader(name string) auth.Option {
+	return withSource(XHeaderParser(name))
+}
+
+// WithQueryParam adds an HTTP query parameter (e.g access_token) as a token extraction source.
+// See WithHeader for the extraction order.
+func WithQueryParam(name string) auth.Option {
+	return withSource(QueryParser(name))
+}
+
+// WithFormParam adds a form-encoded body parameter as a token extraction source,
+// as defined in RFC 6750 section 2.2.
+// See WithHeader for the extraction order.
+func WithFormParam(name string) auth.Option {
+	return withSource(FormParser(name))
+}
+
+func withSource(p Parser) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*core); ok {
+			v.sources = append(v.sources, p)
+		}
+	})
+}
+
 // SetScopes sets the scopes to be used when verifying user access token.
 func SetScopes(scopes ...Scope) auth.Option {
 	return auth.OptionFunc(func(v interface{}) {
@@ -44,3 +73,41 @@ func SetHash(h crypto.Hash, key []byte) auth.Option {
 		}
 	})
 }
+
+// SetNegativeTTL enable caching authentication failures for a duration,
+// which prevents repeated authenticate function calls for invalid tokens,
+// e.g probing attacks. The cached error returned for subsequent requests with the same token.
+// Keep the duration short, as transient errors are cached as well.
+// Only used by the cached token strategy, Default 0 (disabled).
+func SetNegativeTTL(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*cachedToken); ok {
+			v.negativeTTL = d
+		}
+	})
+}
+
+// WithHooks adds event hooks to observe the strategy authentication events,
+// hooks called in the order they were added.
+// Cache events fired by the cached token strategy, keyed on the token hash.
+func WithHooks(hooks ...auth.EventHook) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		switch v := v.(type) {
+		case *core:
+			v.hooks = append(v.hooks, hooks...)
+		case *cachedToken:
+			v.hooks = append(v.hooks, hooks...)
+		}
+	})
+}
+
+// SetBatchConcurrency sets the maximum number of tokens authenticated in parallel,
+// by BatchAuthenticator AuthenticateBatch method.
+// Default Value 10.
+func SetBatchConcurrency(n int) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*core); ok && n > 0 {
+			v.concurrency = n
+		}
+	})
+}
@@ -2,9 +2,15 @@ package token
 
 import (
 	"crypto"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
 )
 
 func TestSetParser(t *testing.T) {
@@ -30,3 +36,100 @@ func TestSetHash(t *testing.T) {
 	assert.True(t, c.hasher != nil)
 	assert.NotEqual(t, token, c.hasher.Hash(token))
 }
+
+func TestSetNegativeTTL(t *testing.T) {
+	c := new(cachedToken)
+	opt := SetNegativeTTL(time.Second)
+	opt.Apply(c)
+	assert.Equal(t, time.Second, c.negativeTTL)
+}
+
+func TestExtractionSources(t *testing.T) {
+	opts := []auth.Option{
+		WithHeader("X-Access-Token"),
+		WithQueryParam("access_token"),
+		WithFormParam("access_token"),
+	}
+
+	form := func(token string) *http.Request {
+		body := url.Values{"access_token": {token}}.Encode()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	table := []struct {
+		name     string
+		prepare  func() *http.Request
+		expected string
+	}{
+		{
+			name: "it extract token from authorization header",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				r.Header.Set("Authorization", "Bearer authz")
+				return r
+			},
+			expected: "authz",
+		},
+		{
+			name: "it extract token from custom header",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				r.Header.Set("X-Access-Token", "header")
+				return r
+			},
+			expected: "header",
+		},
+		{
+			name: "it extract token from query param",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/?access_token=query", nil)
+				return r
+			},
+			expected: "query",
+		},
+		{
+			name: "it extract token from form param",
+			prepare: func() *http.Request {
+				return form("form")
+			},
+			expected: "form",
+		},
+		{
+			name: "it use highest priority source when token provided in multiple places",
+			prepare: func() *http.Request {
+				r := form("form")
+				r.URL.RawQuery = "access_token=query"
+				r.Header.Set("X-Access-Token", "header")
+				return r
+			},
+			expected: "header",
+		},
+		{
+			name: "it prefer authorization header over extraction sources",
+			prepare: func() *http.Request {
+				r, _ := http.NewRequest("GET", "/?access_token=query", nil)
+				r.Header.Set("Authorization", "Bearer authz")
+				return r
+			},
+			expected: "authz",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCore(nil, opts...)
+			token, err := c.parser.Token(tt.prepare())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, token)
+		})
+	}
+}
+
+func TestSetBatchConcurrency(t *testing.T) {
+	c := new(core)
+	opt := SetBatchConcurrency(3)
+	opt.Apply(c)
+	assert.Equal(t, 3, c.concurrency)
+}
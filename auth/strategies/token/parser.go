@@ -61,3 +61,27 @@ func JSONBodyParser(key string) Parser {
 
 	return tokenFn(fn)
 }
+
+// FormParser return a token parser, where token extracted form HTTP request form-encoded body.
+func FormParser(key string) Parser {
+	fn := func(r *http.Request) (string, error) {
+		return internal.ParseForm(key, r, ErrInvalidToken)
+	}
+
+	return tokenFn(fn)
+}
+
+// ChainParser return a token parser, where token extracted from the first parser
+// that successfully extract a token, parsers are queried in the given order.
+func ChainParser(parsers ...Parser) Parser {
+	fn := func(r *http.Request) (string, error) {
+		for _, p := range parsers {
+			if token, err := p.Token(r); err == nil {
+				return token, nil
+			}
+		}
+		return "", ErrInvalidToken
+	}
+
+	return tokenFn(fn)
+}
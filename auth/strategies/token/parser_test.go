@@ -2,6 +2,7 @@ package token
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -121,6 +122,59 @@ func TestParser(t *testing.T) {
 			err:   nil,
 			token: "cookieToken",
 		},
+		{
+			name: "FormParser return error when failed to parse token",
+			prepare: func() (Parser, *http.Request) {
+				req, _ := http.NewRequest("POST", "/", strings.NewReader("other=token"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				parser := FormParser("access_token")
+				return parser, req
+			},
+			err:   ErrInvalidToken,
+			token: "",
+		},
+		{
+			name: "FormParser return token",
+			prepare: func() (Parser, *http.Request) {
+				req, _ := http.NewRequest("POST", "/", strings.NewReader("access_token=form-token"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				parser := FormParser("access_token")
+				return parser, req
+			},
+			err:   nil,
+			token: "form-token",
+		},
+		{
+			name: "ChainParser return error when all parsers failed to parse token",
+			prepare: func() (Parser, *http.Request) {
+				req, _ := http.NewRequest("GET", "/", nil)
+				parser := ChainParser(XHeaderParser("X-API-Key"), QueryParser("api_key"))
+				return parser, req
+			},
+			err:   ErrInvalidToken,
+			token: "",
+		},
+		{
+			name: "ChainParser return token from first succeeded parser",
+			prepare: func() (Parser, *http.Request) {
+				req, _ := http.NewRequest("GET", "/?api_key=query-token", nil)
+				req.Header.Set("X-API-Key", "header-token")
+				parser := ChainParser(XHeaderParser("X-API-Key"), QueryParser("api_key"))
+				return parser, req
+			},
+			err:   nil,
+			token: "header-token",
+		},
+		{
+			name: "ChainParser fallback to next parser",
+			prepare: func() (Parser, *http.Request) {
+				req, _ := http.NewRequest("GET", "/?api_key=query-token", nil)
+				parser := ChainParser(XHeaderParser("X-API-Key"), QueryParser("api_key"))
+				return parser, req
+			},
+			err:   nil,
+			token: "query-token",
+		},
 	}
 
 	for _, tt := range table {
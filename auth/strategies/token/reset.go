@@ -0,0 +1,56 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidResetToken is returned by ValidateResetToken,
+	// when the token malformed or its signature invalid.
+	ErrInvalidResetToken = errors.New("strategies/token: Invalid reset token")
+
+	// ErrExpiredResetToken is returned by ValidateResetToken,
+	// when the token expired.
+	ErrExpiredResetToken = errors.New("strategies/token: Reset token expired")
+)
+
+// GenerateResetToken return URL-safe password reset token for the user, valid for ttl,
+// encoding the expiry and user id signed using HMAC-SHA256 with the secret.
+func GenerateResetToken(userID string, secret []byte, ttl time.Duration) (string, error) {
+	payload := make([]byte, 8, 8+len(userID)+sha256.Size)
+	binary.BigEndian.PutUint64(payload, uint64(time.Now().Add(ttl).UnixNano()))
+	payload = append(payload, userID...)
+	return base64.RawURLEncoding.EncodeToString(append(payload, resetMAC(payload, secret)...)), nil
+}
+
+// ValidateResetToken verifies the reset token signature and expiry,
+// and return the user id it was issued to.
+func ValidateResetToken(token string, secret []byte) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) < 8+sha256.Size {
+		return "", ErrInvalidResetToken
+	}
+
+	payload, mac := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+	if !hmac.Equal(mac, resetMAC(payload, secret)) {
+		return "", ErrInvalidResetToken
+	}
+
+	exp := time.Unix(0, int64(binary.BigEndian.Uint64(payload[:8])))
+	if !time.Now().Before(exp) {
+		return "", ErrExpiredResetToken
+	}
+
+	return string(payload[8:]), nil
+}
+
+func resetMAC(payload, secret []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	_, _ = h.Write(payload)
+	return h.Sum(nil)
+}
@@ -0,0 +1,97 @@
+package token
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetToken(t *testing.T) {
+	secret := []byte("secret")
+
+	tamper := func(tk string, f func(b []byte)) string {
+		b, _ := base64.RawURLEncoding.DecodeString(tk)
+		f(b)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	valid, err := GenerateResetToken("user-1", secret, time.Hour)
+	assert.NoError(t, err)
+
+	expired, err := GenerateResetToken("user-1", secret, -time.Second)
+	assert.NoError(t, err)
+
+	table := []struct {
+		name        string
+		token       string
+		secret      []byte
+		expectedErr error
+	}{
+		{
+			name:   "it return user id when token valid",
+			token:  valid,
+			secret: secret,
+		},
+		{
+			name:        "it return error when token expired",
+			token:       expired,
+			secret:      secret,
+			expectedErr: ErrExpiredResetToken,
+		},
+		{
+			name:        "it return error when user id tampered",
+			token:       tamper(valid, func(b []byte) { b[len(b)-33] = '2' }),
+			secret:      secret,
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name:        "it return error when expiry tampered",
+			token:       tamper(valid, func(b []byte) { b[0]++ }),
+			secret:      secret,
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name:        "it return error when hmac tampered",
+			token:       tamper(valid, func(b []byte) { b[len(b)-1] ^= 1 }),
+			secret:      secret,
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name:        "it return error when secret differ",
+			token:       valid,
+			secret:      []byte("other"),
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name:        "it return error when token malformed",
+			token:       "!!",
+			secret:      secret,
+			expectedErr: ErrInvalidResetToken,
+		},
+		{
+			name:        "it return error when token too short",
+			token:       "c2hvcnQ",
+			secret:      secret,
+			expectedErr: ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ValidateResetToken(tt.token, tt.secret)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, "user-1", id)
+			}
+		})
+	}
+}
+
+func TestResetTokenUnique(t *testing.T) {
+	a, _ := GenerateResetToken("user-1", []byte("secret"), time.Hour)
+	time.Sleep(time.Microsecond)
+	b, _ := GenerateResetToken("user-1", []byte("secret"), time.Hour)
+	assert.NotEqual(t, a, b)
+}
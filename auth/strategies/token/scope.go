@@ -47,6 +47,21 @@ func GetNamedScopes(info auth.Info) (scopes []string) {
 	return info.GetExtensions()[scopesExtName]
 }
 
+// HasScope reports whether auth.info named scopes contain the scope.
+func HasScope(info auth.Info, scope string) bool {
+	if info == nil {
+		return false
+	}
+
+	for _, s := range GetNamedScopes(info) {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
 // NewScope return's a new scope instance.
 // the returned scope verify the request by matching
 // the scope endpoint to the request path and
@@ -41,6 +41,17 @@ func TestWithGetScope(t *testing.T) {
 	}
 }
 
+func TestHasScope(t *testing.T) {
+	info := auth.NewUserInfo("test", "test", nil, nil)
+	assert.False(t, HasScope(info, "read:users"))
+	assert.False(t, HasScope(nil, "read:users"))
+
+	WithNamedScopes(info, "read:users", "write:billing")
+	assert.True(t, HasScope(info, "read:users"))
+	assert.True(t, HasScope(info, "write:billing"))
+	assert.False(t, HasScope(info, "write:users"))
+}
+
 func TestScopeVerify(t *testing.T) {
 	table := []struct {
 		name     string
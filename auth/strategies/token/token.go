@@ -53,19 +53,34 @@ type strategy interface {
 
 type core struct {
 	parser   Parser
+	sources  []Parser
 	strategy strategy
 	hasher   internal.Hasher
 	verify   verify
+	hooks    auth.EventHooks
+	// concurrency bound batch authentication.
+	concurrency int
 }
 
 func (c *core) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	info, err := c.authenticate(ctx, r)
+	if err != nil {
+		c.hooks.OnAuthFailed(err, r)
+		return nil, err
+	}
+
+	c.hooks.OnAuthenticated(info, r)
+	return info, nil
+}
+
+func (c *core) authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
 	token, err := c.parser.Token(r)
 	if err != nil {
 		return nil, err
 	}
 
 	hash := c.hasher.Hash(token)
-	info, err := c.strategy.authenticate(ctx, r, token, hash)
+	info, err := c.strategy.authenticate(ctx, r, hash, token)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +112,7 @@ func newCore(s strategy, opts ...auth.Option) *core {
 	c := new(core)
 	c.strategy = s
 	c.hasher = internal.PlainTextHasher{}
+	c.concurrency = 10
 	c.parser = AuthorizationParser(string(Bearer))
 	c.verify = func(_ context.Context, _ *http.Request, _ auth.Info, _ string) error {
 		return nil
@@ -106,5 +122,9 @@ func newCore(s strategy, opts ...auth.Option) *core {
 		opt.Apply(c)
 	}
 
+	if len(c.sources) > 0 {
+		c.parser = ChainParser(append([]Parser{c.parser}, c.sources...)...)
+	}
+
 	return c
 }
@@ -2,6 +2,7 @@ package union
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/shaj13/go-guardian/v2/auth"
@@ -37,14 +38,18 @@ type Union interface {
 type union []auth.Strategy
 
 func (u union) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
-	_, info, err := u.AuthenticateRequest(r)
+	_, info, err := u.authenticate(ctx, r)
 	return info, err
 }
 
 func (u union) AuthenticateRequest(r *http.Request) (auth.Strategy, auth.Info, error) {
+	return u.authenticate(r.Context(), r)
+}
+
+func (u union) authenticate(ctx context.Context, r *http.Request) (auth.Strategy, auth.Info, error) {
 	errs := MultiError{}
 	for _, s := range u {
-		info, err := s.Authenticate(r.Context(), r)
+		info, err := s.Authenticate(ctx, r)
 		if err == nil {
 			return s, info, nil
 		}
@@ -58,6 +63,13 @@ func (u union) Chain() []auth.Strategy {
 }
 
 // New returns new union strategy.
+// The strategies tried in the given order, and the first successful strategy short-circuit the chain.
+// New panics if any of the strategies is nil.
 func New(strategies ...auth.Strategy) Union {
+	for i, s := range strategies {
+		if s == nil {
+			panic(fmt.Sprintf("strategies/union: nil strategy at index %d", i))
+		}
+	}
 	return union(strategies)
 }
@@ -0,0 +1,110 @@
+package union
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestUnion(t *testing.T) {
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	table := []struct {
+		name             string
+		strategies       []*mockStrategy
+		expectedErr      error
+		expectedStrategy int
+		expectedCalls    []int
+	}{
+		{
+			name: "it return info when first strategy succeeds",
+			strategies: []*mockStrategy{
+				{info: info},
+				{info: info},
+			},
+			expectedStrategy: 0,
+			expectedCalls:    []int{1, 0},
+		},
+		{
+			name: "it return info when first strategy fails and second succeeds",
+			strategies: []*mockStrategy{
+				{err: errFirst},
+				{info: info},
+			},
+			expectedStrategy: 1,
+			expectedCalls:    []int{1, 1},
+		},
+		{
+			name: "it return multi error when all strategies fail",
+			strategies: []*mockStrategy{
+				{err: errFirst},
+				{err: errSecond},
+			},
+			expectedErr:   MultiError{errFirst, errSecond},
+			expectedCalls: []int{1, 1},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := []auth.Strategy{}
+			for _, s := range tt.strategies {
+				chain = append(chain, s)
+			}
+
+			r, _ := http.NewRequest("GET", "/", nil)
+			s, got, err := New(chain...).AuthenticateRequest(r)
+
+			for i, calls := range tt.expectedCalls {
+				assert.Equal(t, calls, tt.strategies[i].calls)
+			}
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.EqualError(t, err, "strategies/union: [first, second]")
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, info, got)
+			assert.Equal(t, chain[tt.expectedStrategy], s)
+		})
+	}
+}
+
+func TestUnionContext(t *testing.T) {
+	type key struct{}
+	s := new(mockStrategy)
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	r, _ := http.NewRequest("GET", "/", nil)
+
+	_, _ = New(s).Authenticate(ctx, r)
+
+	assert.Equal(t, "value", s.ctx.Value(key{}))
+}
+
+func TestNewPanicsOnNilStrategy(t *testing.T) {
+	assert.PanicsWithValue(t, "strategies/union: nil strategy at index 1", func() {
+		New(new(mockStrategy), nil)
+	})
+}
+
+type mockStrategy struct {
+	info  auth.Info
+	err   error
+	calls int
+	ctx   context.Context
+}
+
+func (m *mockStrategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	m.calls++
+	m.ctx = ctx
+	return m.info, m.err
+}
@@ -0,0 +1,45 @@
+package vault
+
+import (
+	"crypto/tls"
+	"net/http"
+	"strings"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+)
+
+// SetHTTPClient sets underlying http client.
+func SetHTTPClient(c *http.Client) auth.Option {
+	return internal.SetRequesterHTTPClient(c)
+}
+
+// SetTLSConfig sets underlying http client tls.
+func SetTLSConfig(tls *tls.Config) auth.Option {
+	return internal.SetRequesterTLSConfig(tls)
+}
+
+// SetClientTransport sets underlying http client transport.
+func SetClientTransport(rt http.RoundTripper) auth.Option {
+	return internal.SetRequesterClientTransport(rt)
+}
+
+// SetMountPath sets the token auth method mount path.
+// Default: token
+func SetMountPath(path string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if k, ok := v.(*vault); ok {
+			k.mount = strings.Trim(path, "/")
+		}
+	})
+}
+
+// SetNamespace sets the vault enterprise namespace,
+// sent in the X-Vault-Namespace header.
+func SetNamespace(ns string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if r, ok := v.(*internal.Requester); ok {
+			r.SetHeader("X-Vault-Namespace", ns)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+// Package vault provide auth strategy to authenticate,
+// incoming HTTP requests using HashiCorp Vault tokens,
+// by looking up the token using the token auth method lookup-self endpoint.
+// See https://www.vaultproject.io/api-docs/auth/token#lookup-a-token-self.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/internal"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+)
+
+// HeaderToken is the request header vault tokens sent in.
+const HeaderToken = "X-Vault-Token"
+
+type lookup struct {
+	Data struct {
+		Accessor    string            `json:"accessor"`
+		DisplayName string            `json:"display_name"`
+		EntityID    string            `json:"entity_id"`
+		ExpireTime  *time.Time        `json:"expire_time"`
+		Policies    []string          `json:"policies"`
+		TTL         int64             `json:"ttl"`
+		Meta        map[string]string `json:"meta"`
+	} `json:"data"`
+}
+
+type vaultError struct {
+	Errors []string `json:"errors"`
+}
+
+func (e vaultError) Error() string {
+	return strings.Join(e.Errors, ", ")
+}
+
+type vault struct {
+	requester *internal.Requester
+	mount     string
+}
+
+func (v *vault) authenticate(ctx context.Context, r *http.Request, tokenstr string) (auth.Info, time.Time, error) { //nolint:lll
+	t := time.Time{}
+	res := new(lookup)
+	verr := new(vaultError)
+	f := func(r *http.Request) {
+		r.Header.Set(HeaderToken, tokenstr)
+	}
+	fail := func(err error) (auth.Info, time.Time, error) {
+		return nil, t, fmt.Errorf("strategies/vault: %w", err)
+	}
+
+	//nolint:bodyclose
+	resp, err := v.requester.DoWithf(ctx, f, nil, res, verr)
+
+	switch {
+	case err != nil:
+		return fail(err)
+	case resp.StatusCode != http.StatusOK && len(verr.Errors) > 0:
+		return fail(verr)
+	case resp.StatusCode != http.StatusOK:
+		return fail(fmt.Errorf("Vault returned %v status code", resp.StatusCode))
+	}
+
+	d := res.Data
+	switch {
+	case d.ExpireTime != nil:
+		t = *d.ExpireTime
+	case d.TTL > 0:
+		t = time.Now().Add(time.Duration(d.TTL) * time.Second)
+	}
+
+	id := d.EntityID
+	if len(id) == 0 {
+		id = d.Accessor
+	}
+
+	ext := auth.Extensions{}
+	ext.Set("accessor", d.Accessor)
+	for k, val := range d.Meta {
+		ext.Set(k, val)
+	}
+
+	return auth.NewUserInfo(d.DisplayName, id, d.Policies, ext), t, nil
+}
+
+// GetAuthenticateFunc return function to authenticate request using vault tokens.
+// The returned function typically used with the token strategy.
+func GetAuthenticateFunc(addr string, opts ...auth.Option) token.AuthenticateFunc {
+	return newVault(addr, opts...).authenticate
+}
+
+// New return strategy authenticate request using vault tokens,
+// sent as a bearer token or in the X-Vault-Token header.
+// The user info groups are the token policies,
+// and the token cached until its vault ttl expires.
+//
+// New is similar to:
+//
+// 		fn := vault.GetAuthenticateFunc(addr, opts...)
+// 		token.New(fn, cache, opts...)
+//
+func New(addr string, c auth.Cache, opts ...auth.Option) auth.Strategy {
+	fn := GetAuthenticateFunc(addr, opts...)
+	opts = append([]auth.Option{token.WithHeader(HeaderToken)}, opts...)
+	return token.New(fn, c, opts...)
+}
+
+func newVault(addr string, opts ...auth.Option) *vault {
+	r := internal.NewRequester(addr)
+	r.Method = http.MethodGet
+	r.KeepUnmarshalling = true
+
+	v := new(vault)
+	v.requester = r
+	v.mount = "token"
+
+	for _, opt := range opts {
+		opt.Apply(v)
+		opt.Apply(v.requester)
+	}
+
+	r.Endpoint = "/v1/auth/" + v.mount + "/lookup-self"
+
+	return v
+}
@@ -0,0 +1,120 @@
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+const lookupSelf = `{
+  "data": {
+    "accessor": "8609694a-cdbc-db9b-d345-e782dbb562ed",
+    "display_name": "approle-web",
+    "entity_id": "7d2e3179-f69b-450c-7179-ac8ee8bd8ca9",
+    "expire_time": "2100-01-01T00:00:00Z",
+    "policies": ["default", "web"],
+    "ttl": 2764800,
+    "meta": {"role_name": "web"}
+  }
+}`
+
+func TestVault(t *testing.T) {
+	srv := mockVault(t)
+	defer srv.Close()
+
+	table := []struct {
+		name        string
+		token       string
+		opts        []auth.Option
+		expectedErr string
+		expiresAt   time.Time
+	}{
+		{
+			name:        "it return error when vault reject token",
+			token:       "invalid",
+			expectedErr: "permission denied",
+		},
+		{
+			name:      "it return user info with token expire time",
+			token:     "valid",
+			expiresAt: time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "it return error when vault return unexpected status",
+			token:       "valid",
+			opts:        []auth.Option{SetMountPath("/other/")},
+			expectedErr: "404 status code",
+		},
+		{
+			name:  "it lookup token using namespace and mount path",
+			token: "valid",
+			opts:  []auth.Option{SetMountPath("/ns-token/"), SetNamespace("team")},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := GetAuthenticateFunc(srv.URL, tt.opts...)
+			info, exp, err := fn(context.TODO(), nil, tt.token)
+
+			if len(tt.expectedErr) > 0 {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "approle-web", info.GetUserName())
+			assert.Equal(t, "7d2e3179-f69b-450c-7179-ac8ee8bd8ca9", info.GetID())
+			assert.Equal(t, []string{"default", "web"}, info.GetGroups())
+			assert.Equal(t, "web", info.GetExtensions().Get("role_name"))
+			if !tt.expiresAt.IsZero() {
+				assert.True(t, tt.expiresAt.Equal(exp))
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	srv := mockVault(t)
+	defer srv.Close()
+	s := New(srv.URL, libcache.LRU.New(0))
+
+	for _, h := range []string{HeaderToken, "Authorization"} {
+		r, _ := http.NewRequest("GET", "/", nil)
+		if h == HeaderToken {
+			r.Header.Set(h, "valid")
+		} else {
+			r.Header.Set(h, "Bearer valid")
+		}
+
+		info, err := s.Authenticate(r.Context(), r)
+		assert.NoError(t, err)
+		assert.Equal(t, "approle-web", info.GetUserName())
+	}
+}
+
+func mockVault(tb testing.TB) *httptest.Server {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get(HeaderToken) != "valid":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		case r.URL.Path == "/v1/auth/token/lookup-self":
+			_, _ = w.Write([]byte(lookupSelf))
+		case r.URL.Path == "/v1/auth/ns-token/lookup-self" && r.Header.Get("X-Vault-Namespace") == "team":
+			_, _ = w.Write([]byte(lookupSelf))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	return httptest.NewServer(http.HandlerFunc(h))
+}
@@ -4,8 +4,10 @@ import (
 	"crypto/tls"
 	crypto_x509 "crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/shaj13/go-guardian/v2/auth"
 	"github.com/shaj13/go-guardian/v2/auth/strategies/x509"
@@ -109,11 +111,12 @@ func Example() {
 	// validate expired client certificate
 	req.TLS = &tls.ConnectionState{PeerCertificates: []*crypto_x509.Certificate{ParseCertificate(expired)}}
 	info, err = strategy.Authenticate(req.Context(), req)
-	fmt.Println(info, err)
+	invalid := crypto_x509.CertificateInvalidError{}
+	fmt.Println(info, errors.As(err, &invalid) && invalid.Reason == crypto_x509.Expired)
 
 	// Output:
 	// host.test.com <nil>
-	// <nil> x509: certificate has expired or is not yet valid
+	// <nil> true
 }
 
 func ExampleInfoBuilder() {
@@ -192,5 +195,7 @@ func CreateVerifyOptions() crypto_x509.VerifyOptions {
 	opts.Roots = crypto_x509.NewCertPool()
 	// Read Root Ca Certificate
 	opts.Roots.AddCert(ParseCertificate(ca))
+	// Pin verification time within the example certificates validity period.
+	opts.CurrentTime = time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
 	return opts
 }
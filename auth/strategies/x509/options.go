@@ -1,6 +1,7 @@
 package x509
 
 import (
+	"crypto/x509/pkix"
 	"regexp"
 
 	"github.com/shaj13/go-guardian/v2/auth"
@@ -53,3 +54,15 @@ func SetAllowedCNRegex(str string) auth.Option {
 		}
 	})
 }
+
+// SetCRL sets the certificate revocation lists,
+// used to reject verified chains that contain a revoked certificate.
+// Each list checked against the certificates issued by the list issuer,
+// and the chain rejected with ErrExpiredCRL once the list passed its next update time.
+func SetCRL(crls ...*pkix.CertificateList) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if s, ok := v.(*strategy); ok {
+			s.crls = crls
+		}
+	})
+}
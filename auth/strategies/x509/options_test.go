@@ -2,6 +2,7 @@ package x509
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,3 +31,10 @@ func TestSetAllowedCNRegex(t *testing.T) {
 		assert.True(t, s.(*strategy).allowedCN(cn))
 	}
 }
+
+func TestSetCRL(t *testing.T) {
+	crl := new(pkix.CertificateList)
+	opt := SetCRL(crl)
+	s := New(x509.VerifyOptions{}, opt)
+	assert.Equal(t, []*pkix.CertificateList{crl}, s.(*strategy).crls)
+}
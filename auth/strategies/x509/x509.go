@@ -5,9 +5,11 @@ package x509
 import (
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/shaj13/go-guardian/v2/auth"
 )
@@ -18,6 +20,15 @@ var (
 
 	// ErrMissingCN is returned by DefaultBuilder when Certificate CommonName missing.
 	ErrMissingCN = errors.New("strategies/x509: Certificate subject CN missing")
+
+	// ErrRevoked is returned by x509 strategy when a certificate in the verified chain,
+	// listed in one of the certificate revocation lists.
+	ErrRevoked = errors.New("strategies/x509: Certificate has been revoked")
+
+	// ErrExpiredCRL is returned by x509 strategy when a certificate revocation list,
+	// of a verified chain certificate issuer passed its next update time,
+	// hence it might miss recent revocations.
+	ErrExpiredCRL = errors.New("strategies/x509: Certificate revocation list has expired")
 )
 
 // InfoBuilder declare a function signature for building Info from certificate chain.
@@ -40,6 +51,7 @@ type strategy struct {
 	builder   InfoBuilder
 	emptyCN   bool
 	allowedCN func(string) bool
+	crls      []*pkix.CertificateList
 }
 
 func (s strategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
@@ -66,9 +78,53 @@ func (s strategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info,
 		return nil, err
 	}
 
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if err := s.checkRevocation(chain, now); err != nil {
+		return nil, err
+	}
+
 	return s.build(chain)
 }
 
+// checkRevocation verify that non of the verified chains certificates
+// revoked by an issuer certificate revocation list, and the list not expired at now.
+func (s strategy) checkRevocation(chains [][]*x509.Certificate, now time.Time) error {
+	for _, crl := range s.crls {
+		crlIssuer := new(pkix.Name)
+		crlIssuer.FillFromRDNSequence(&crl.TBSCertList.Issuer)
+
+		for _, chain := range chains {
+			for i := 0; i < len(chain)-1; i++ {
+				cert, issuer := chain[i], chain[i+1]
+
+				if issuer.Subject.String() != crlIssuer.String() {
+					continue
+				}
+
+				if err := issuer.CheckCRLSignature(crl); err != nil {
+					return fmt.Errorf("strategies/x509: Invalid certificate revocation list, %w", err)
+				}
+
+				if crl.HasExpired(now) {
+					return ErrExpiredCRL
+				}
+
+				for _, revoked := range crl.TBSCertList.RevokedCertificates {
+					if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
+						return ErrRevoked
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func (s strategy) build(chain [][]*x509.Certificate) (auth.Info, error) {
 	cn := chain[0][0].Subject.CommonName
 
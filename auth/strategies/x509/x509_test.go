@@ -1,15 +1,21 @@
 package x509
 
 import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -149,6 +155,102 @@ func TestStrategyBuild(t *testing.T) {
 	}
 }
 
+func TestStrategyCRL(t *testing.T) {
+	ca, caKey := generateCert(t, "ca", nil, nil)
+	valid, _ := generateCert(t, "valid", ca, caKey)
+	revoked, _ := generateCert(t, "revoked", ca, caKey)
+	fake, fakeKey := generateCert(t, "ca", nil, nil)
+	other, otherKey := generateCert(t, "other", nil, nil)
+
+	crl := func(issuer *x509.Certificate, key crypto.Signer, next time.Duration, certs ...*x509.Certificate) *pkix.CertificateList { //nolint:lll
+		list := []pkix.RevokedCertificate{}
+		for _, c := range certs {
+			list = append(list, pkix.RevokedCertificate{
+				SerialNumber:   c.SerialNumber,
+				RevocationTime: time.Now(),
+			})
+		}
+		der, err := issuer.CreateCRL(rand.Reader, key, list, time.Now().Add(-time.Hour*2), time.Now().Add(next))
+		if err != nil {
+			t.Fatal(err)
+		}
+		crl, err := x509.ParseCRL(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return crl
+	}
+
+	table := []struct {
+		name string
+		cert *x509.Certificate
+		crls []*pkix.CertificateList
+		err  error
+	}{
+		{
+			name: "it authenticate certificate when no crl set",
+			cert: revoked,
+		},
+		{
+			name: "it authenticate certificate not listed in crl",
+			cert: valid,
+			crls: []*pkix.CertificateList{crl(ca, caKey, time.Hour, revoked)},
+		},
+		{
+			name: "it return error when certificate listed in crl",
+			cert: revoked,
+			crls: []*pkix.CertificateList{crl(ca, caKey, time.Hour, revoked)},
+			err:  ErrRevoked,
+		},
+		{
+			name: "it return error when crl signature invalid",
+			cert: valid,
+			crls: []*pkix.CertificateList{crl(fake, fakeKey, time.Hour)},
+			err:  errors.New("strategies/x509: Invalid certificate revocation list, crypto/rsa: verification error"),
+		},
+		{
+			name: "it return error when crl expired",
+			cert: valid,
+			crls: []*pkix.CertificateList{crl(ca, caKey, -time.Hour)},
+			err:  ErrExpiredCRL,
+		},
+		{
+			name: "it ignore expired crl of another issuer",
+			cert: valid,
+			crls: []*pkix.CertificateList{crl(ca, caKey, time.Hour), crl(other, otherKey, -time.Hour)},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := x509.VerifyOptions{}
+			opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+			opts.Roots = x509.NewCertPool()
+			opts.Roots.AddCert(ca)
+
+			strategy := New(opts, SetCRL(tt.crls...))
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{tt.cert}}
+
+			info, err := strategy.Authenticate(r.Context(), r)
+
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cert.Subject.CommonName, info.GetUserName())
+		})
+	}
+
+	t.Run("it return error when certificate revoked in any verified chain", func(t *testing.T) {
+		s := strategy{crls: []*pkix.CertificateList{crl(ca, caKey, time.Hour, revoked)}}
+		chains := [][]*x509.Certificate{{revoked, other}, {revoked, ca}}
+		assert.Equal(t, ErrRevoked, s.checkRevocation(chains, time.Now()))
+	})
+}
+
 func BenchmarkX509(b *testing.B) {
 	opts := testVerifyOptions(b)
 	strategy := New(opts)
@@ -174,6 +276,8 @@ func testVerifyOptions(tb testing.TB) x509.VerifyOptions {
 	opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
 	opts.Roots = x509.NewCertPool()
 	opts.Roots.AddCert(readCert(tb, "ca")[0])
+	// testdata certificates valid from 2020-05-13 to 2021-05-13.
+	opts.CurrentTime = time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)
 	return opts
 }
 
@@ -184,6 +288,44 @@ func testChain(cn string) [][]*x509.Certificate {
 	return [][]*x509.Certificate{{&cert}}
 }
 
+// generateCert generate a certificate signed by parent,
+// or a self-signed ca certificate when parent is nil.
+func generateCert(tb testing.TB, cn string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) { //nolint:lll
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return cert, key
+}
+
 func readCert(tb testing.TB, files ...string) []*x509.Certificate {
 	certs := []*x509.Certificate{}
 
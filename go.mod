@@ -7,6 +7,7 @@ require (
 	github.com/golang/gddo v0.0.0-20210115222349-20d68f94ee1f
 	github.com/shaj13/libcache v1.0.0
 	github.com/stretchr/testify v1.6.1
+	golang.org/x/crypto v0.0.0-20200604202706-70a84ac30bf9
 	gopkg.in/square/go-jose.v2 v2.5.1
 	k8s.io/api v0.18.8
 	k8s.io/apimachinery v0.18.8
@@ -0,0 +1,159 @@
+// Package audit provides HTTP middleware that authenticate requests,
+// and records every authentication attempt as a JSON line to an io.Writer.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+const (
+	// Success outcome of an authenticated request.
+	Success = "success"
+	// Failure outcome of a request that failed authentication.
+	Failure = "failure"
+	// Redacted replace sensitive header values in audit events.
+	Redacted = "[REDACTED]"
+)
+
+// Event represents an audit record of an authentication attempt.
+type Event struct {
+	Time     time.Time         `json:"time"`
+	Method   string            `json:"method"`
+	Path     string            `json:"path"`
+	User     string            `json:"user,omitempty"`
+	SourceIP string            `json:"source_ip"`
+	Status   int               `json:"status"`
+	Latency  float64           `json:"latency_ms"`
+	Outcome  string            `json:"outcome"`
+	Reason   string            `json:"reason,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// Logger writes audit events as JSON lines to the underlying writer.
+type Logger struct {
+	mu        sync.Mutex
+	enc       *json.Encoder
+	logged    []string
+	sensitive map[string]struct{}
+	now       func() time.Time
+}
+
+// Middleware return HTTP handler, that authenticate requests using the strategy,
+// and records an audit event for each request once it served.
+// Failed authentication reply with 401 Unauthorized,
+// the event reason holds the strategy error, credentials never recorded.
+func (l *Logger) Middleware(s auth.Strategy, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := l.now()
+		rw := &recorder{ResponseWriter: w, status: http.StatusOK}
+		e := l.event(r)
+
+		info, err := s.Authenticate(r.Context(), r)
+		if err != nil {
+			e.Outcome = Failure
+			e.Reason = err.Error()
+			code := http.StatusUnauthorized
+			http.Error(rw, http.StatusText(code), code)
+		} else {
+			e.Outcome = Success
+			e.User = info.GetUserName()
+			next.ServeHTTP(rw, auth.RequestWithUser(info, r))
+		}
+
+		e.Time = start.UTC()
+		e.Status = rw.status
+		e.Latency = float64(l.now().Sub(start)) / float64(time.Millisecond)
+		l.Log(e)
+	})
+}
+
+// Log writes the event as a JSON line.
+func (l *Logger) Log(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(e)
+}
+
+func (l *Logger) event(r *http.Request) Event {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	var headers map[string]string
+	for _, k := range l.logged {
+		v, ok := r.Header[k]
+		if !ok {
+			continue
+		}
+
+		if headers == nil {
+			headers = make(map[string]string, len(l.logged))
+		}
+
+		if _, ok := l.sensitive[k]; ok {
+			headers[k] = Redacted
+			continue
+		}
+
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	return Event{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		SourceIP: host,
+		Headers:  headers,
+	}
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *recorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// sensitiveHeaders carry credentials read by the strategies,
+// and redacted by default even when logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"X-API-Key",
+	"X-Vault-Token",
+	"X-Goog-IAP-JWT-Assertion",
+	"Signature",
+	"Signature-Input",
+}
+
+// New return audit logger writes to w.
+// By default, no request headers recorded, use SetLoggedHeaders to record headers.
+// Logged Authorization, Proxy-Authorization, Cookie, X-API-Key, X-Vault-Token,
+// X-Goog-IAP-JWT-Assertion, Signature and Signature-Input headers always redacted.
+func New(w io.Writer, opts ...auth.Option) *Logger {
+	l := new(Logger)
+	l.enc = json.NewEncoder(w)
+	l.now = time.Now
+	l.sensitive = make(map[string]struct{})
+	for _, h := range sensitiveHeaders {
+		l.sensitive[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+
+	for _, opt := range opts {
+		opt.Apply(l)
+	}
+
+	return l
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestLoggerMiddleware(t *testing.T) {
+	table := []struct {
+		name     string
+		strategy auth.Strategy
+		status   int
+		expected map[string]interface{}
+	}{
+		{
+			name: "it record successful authentication",
+			strategy: strategy(func() (auth.Info, error) {
+				return auth.NewDefaultUser("test", "1", nil, nil), nil
+			}),
+			status: http.StatusCreated,
+			expected: map[string]interface{}{
+				"method":     "POST",
+				"path":       "/resource",
+				"user":       "test",
+				"source_ip":  "10.0.0.1",
+				"status":     float64(http.StatusCreated),
+				"latency_ms": float64(1500),
+				"outcome":    Success,
+				"time":       "2020-01-01T00:00:00Z",
+				"headers": map[string]interface{}{
+					"Authorization": Redacted,
+					"Cookie":        Redacted,
+					"X-Api-Key":     Redacted,
+					"User-Agent":    "test-agent",
+				},
+			},
+		},
+		{
+			name: "it record failed authentication reason",
+			strategy: strategy(func() (auth.Info, error) {
+				return nil, errors.New("strategies/basic: Invalid user credentials")
+			}),
+			expected: map[string]interface{}{
+				"method":     "POST",
+				"path":       "/resource",
+				"source_ip":  "10.0.0.1",
+				"status":     float64(http.StatusUnauthorized),
+				"latency_ms": float64(1500),
+				"outcome":    Failure,
+				"reason":     "strategies/basic: Invalid user credentials",
+				"time":       "2020-01-01T00:00:00Z",
+				"headers": map[string]interface{}{
+					"Authorization": Redacted,
+					"Cookie":        Redacted,
+					"X-Api-Key":     Redacted,
+					"User-Agent":    "test-agent",
+				},
+			},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			l := New(buf,
+				SetLoggedHeaders("Authorization", "Cookie", "X-API-Key", "User-Agent"),
+				SetRedactedHeaders("X-API-Key"),
+			)
+			now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+			l.now = func() time.Time {
+				defer func() { now = now.Add(time.Millisecond * 1500) }()
+				return now
+			}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NotNil(t, auth.User(r))
+				w.WriteHeader(tt.status)
+			})
+
+			r := httptest.NewRequest("POST", "/resource", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			r.SetBasicAuth("test", "secret-password")
+			r.Header.Set("Cookie", "session=secret-session")
+			r.Header.Set("X-API-Key", "secret-key")
+			r.Header.Set("User-Agent", "test-agent")
+
+			l.Middleware(tt.strategy, next).ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.NotContains(t, buf.String(), "secret")
+			assert.Equal(t, byte('\n'), buf.Bytes()[buf.Len()-1], "event must be a JSON line")
+
+			got := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+type strategy func() (auth.Info, error)
+
+func (s strategy) Authenticate(_ context.Context, _ *http.Request) (auth.Info, error) {
+	return s()
+}
+
+func TestLoggerDefaultRedactedHeaders(t *testing.T) {
+	headers := map[string]string{
+		"X-API-Key":                "secret-api-key",
+		"X-Vault-Token":            "secret-vault-token",
+		"X-Goog-IAP-JWT-Assertion": "secret-iap-jwt",
+		"Signature":                "sig1=:secret-signature:",
+		"Signature-Input":          `sig1=("@method");keyid="secret-key-id"`,
+		"Accept":                   "application/json",
+	}
+
+	logged := make([]string, 0, len(headers))
+	for k := range headers {
+		logged = append(logged, k)
+	}
+
+	buf := new(bytes.Buffer)
+	l := New(buf, SetLoggedHeaders(logged...))
+
+	ok := strategy(func() (auth.Info, error) {
+		return auth.NewDefaultUser("test", "1", nil, nil), nil
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+
+	l.Middleware(ok, next).ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.NotContains(t, buf.String(), "secret")
+
+	got := Event{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, map[string]string{
+		"X-Api-Key":                Redacted,
+		"X-Vault-Token":            Redacted,
+		"X-Goog-Iap-Jwt-Assertion": Redacted,
+		"Signature":                Redacted,
+		"Signature-Input":          Redacted,
+		"Accept":                   "application/json",
+	}, got.Headers)
+}
+
+func TestLoggerDefaultNoHeaders(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := New(buf)
+
+	ok := strategy(func() (auth.Info, error) {
+		return auth.NewDefaultUser("test", "1", nil, nil), nil
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Auth-Token", "secret-token")
+	r.Header.Set("X-CSRF-Token", "secret-csrf")
+	r.Header.Set("X-Request-Nonce", "secret-nonce")
+	r.Header.Set("Accept", "application/json")
+
+	l.Middleware(ok, next).ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.NotContains(t, buf.String(), "secret")
+
+	got := Event{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Nil(t, got.Headers)
+}
@@ -0,0 +1,34 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+	"github.com/shaj13/go-guardian/v2/middleware/audit"
+)
+
+func ExampleLogger() {
+	strategy := basic.New(func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		if userName == "example" && password == "example" {
+			return auth.NewDefaultUser("example", "1", nil, nil), nil
+		}
+		return nil, fmt.Errorf("Invalid credentials")
+	})
+
+	logger := audit.New(os.Stdout)
+	handler := logger.Middleware(strategy, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "Hello %s", auth.User(r).GetUserName())
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("example", "example")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	fmt.Println(w.Body.String())
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetLoggedHeaders sets the request headers recorded in audit events,
+// only listed headers recorded, as custom headers might carry credentials.
+// Default: no headers recorded.
+func SetLoggedHeaders(headers ...string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if l, ok := v.(*Logger); ok {
+			for _, h := range headers {
+				l.logged = append(l.logged, http.CanonicalHeaderKey(h))
+			}
+		}
+	})
+}
+
+// SetRedactedHeaders adds logged headers to be redacted in audit events,
+// in addition to the default sensitive headers.
+func SetRedactedHeaders(headers ...string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if l, ok := v.(*Logger); ok {
+			for _, h := range headers {
+				l.sensitive[http.CanonicalHeaderKey(h)] = struct{}{}
+			}
+		}
+	})
+}
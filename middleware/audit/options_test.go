@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRedactedHeaders(t *testing.T) {
+	l := New(ioutil.Discard, SetRedactedHeaders("x-api-key"))
+	assert.Contains(t, l.sensitive, "X-Api-Key")
+	assert.Contains(t, l.sensitive, "Authorization")
+}
+
+func TestSetLoggedHeaders(t *testing.T) {
+	l := New(ioutil.Discard, SetLoggedHeaders("user-agent", "X-Request-ID"))
+	assert.Equal(t, []string{"User-Agent", "X-Request-Id"}, l.logged)
+}
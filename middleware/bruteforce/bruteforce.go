@@ -0,0 +1,168 @@
+// Package bruteforce provides protection against brute-force attacks,
+// by temporarily locking identities after repeated authentication failures,
+// with an exponential back-off between lockouts.
+package bruteforce
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// KeyFunc declare function signature to derive the identity key from HTTP request.
+type KeyFunc func(r *http.Request) string
+
+// DefaultKey return the basic auth user name if present, Otherwise the request remote IP.
+func DefaultKey(r *http.Request) string {
+	if name, _, ok := r.BasicAuth(); ok && len(name) > 0 {
+		return "user:" + name
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+type state struct {
+	failures int
+	lockouts int
+	until    time.Time
+}
+
+// Protector tracks authentication failures per key,
+// and block the key once failures reach max attempts.
+// Each consecutive lockout doubles the lockout duration up to max lockout.
+type Protector struct {
+	maxAttempts int
+	lockout     time.Duration
+	maxLockout  time.Duration
+	key         KeyFunc
+	cache       auth.Cache
+	mu          sync.Mutex
+	now         func() time.Time
+}
+
+// RecordFailure records an authentication failure for the key.
+func (p *Protector) RecordFailure(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	st := p.load(key)
+	st.failures++
+
+	if st.failures >= p.maxAttempts {
+		st.failures = 0
+		st.lockouts++
+		st.until = now.Add(p.duration(st.lockouts))
+	}
+
+	// keep the key state, until a max lockout passes without failures.
+	ttl := p.maxLockout
+	if st.until.After(now) {
+		ttl += st.until.Sub(now)
+	}
+
+	p.cache.StoreWithTTL(key, st, ttl)
+}
+
+// IsBlocked reports whether the key is locked out.
+func (p *Protector) IsBlocked(key string) bool {
+	return p.RetryAfter(key) > 0
+}
+
+// RetryAfter returns the remaining lockout duration of the key.
+func (p *Protector) RetryAfter(key string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if d := p.load(key).until.Sub(p.now()); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// Reset clears the key failures and lockouts,
+// typically called after a successful authentication.
+func (p *Protector) Reset(key string) {
+	p.cache.Delete(key)
+}
+
+// Middleware return HTTP handler, that authenticate requests using the strategy,
+// and reply with 429 Too Many Requests and Retry-After header, while the request key is blocked.
+// Failed authentication recorded and reply with 401 Unauthorized,
+// successful authentication reset the key.
+func (p *Protector) Middleware(s auth.Strategy, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := p.key(r)
+
+		if d := p.RetryAfter(key); d > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+			code := http.StatusTooManyRequests
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		info, err := s.Authenticate(r.Context(), r)
+		if err != nil {
+			p.RecordFailure(key)
+			code := http.StatusUnauthorized
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		p.Reset(key)
+		next.ServeHTTP(w, auth.RequestWithUser(info, r))
+	})
+}
+
+func (p *Protector) load(key string) state {
+	if v, ok := p.cache.Load(key); ok {
+		if st, ok := v.(state); ok {
+			return st
+		}
+	}
+	return state{}
+}
+
+// duration return the nth lockout duration.
+func (p *Protector) duration(n int) time.Duration {
+	d := p.lockout
+	for i := 1; i < n && d < p.maxLockout; i++ {
+		d *= 2
+	}
+
+	if d > p.maxLockout {
+		return p.maxLockout
+	}
+
+	return d
+}
+
+// New return brute-force protector, that stores keys state in c.
+// By default, keys locked for 1 Minute after 5 failed attempts,
+// and lockout duration doubles up to 1 Hour.
+func New(c auth.Cache, opts ...auth.Option) *Protector {
+	p := new(Protector)
+	p.cache = c
+	p.maxAttempts = 5
+	p.lockout = time.Minute
+	p.maxLockout = time.Hour
+	p.key = DefaultKey
+	p.now = time.Now
+
+	for _, opt := range opts {
+		opt.Apply(p)
+	}
+
+	return p
+}
@@ -0,0 +1,109 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestProtectorBackOff(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	p := New(
+		libcache.LRU.New(0),
+		SetMaxAttempts(3),
+		SetLockout(time.Minute),
+		SetMaxLockout(time.Minute*5),
+	)
+	p.now = func() time.Time { return now }
+
+	fail := func(n int) {
+		for i := 0; i < n; i++ {
+			p.RecordFailure("key")
+		}
+	}
+
+	// expected lockout schedule: 1m, 2m, 4m, 5m (capped), 5m.
+	for _, expected := range []time.Duration{1, 2, 4, 5, 5} {
+		fail(2)
+		assert.False(t, p.IsBlocked("key"))
+
+		fail(1)
+		assert.True(t, p.IsBlocked("key"))
+		assert.Equal(t, expected*time.Minute, p.RetryAfter("key"))
+		assert.False(t, p.IsBlocked("other"), "lockout must be per key")
+
+		now = now.Add(expected * time.Minute)
+		assert.False(t, p.IsBlocked("key"))
+	}
+}
+
+func TestProtectorReset(t *testing.T) {
+	p := New(libcache.LRU.New(0), SetMaxAttempts(1))
+	p.RecordFailure("key")
+	assert.True(t, p.IsBlocked("key"))
+
+	p.Reset("key")
+	assert.False(t, p.IsBlocked("key"))
+	assert.Equal(t, time.Duration(0), p.RetryAfter("key"))
+
+	// back-off schedule restart after reset.
+	p.RecordFailure("key")
+	assert.InDelta(t, time.Minute, p.RetryAfter("key"), float64(time.Second))
+}
+
+func TestProtectorMiddleware(t *testing.T) {
+	p := New(libcache.LRU.New(0), SetMaxAttempts(2))
+	strategy := strategyFunc(func(r *http.Request) (auth.Info, error) {
+		if _, pass, _ := r.BasicAuth(); pass == "valid" {
+			return auth.NewDefaultUser("test", "1", nil, nil), nil
+		}
+		return nil, errors.New("invalid credentials")
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := p.Middleware(strategy, next)
+
+	serve := func(user, pass string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.SetBasicAuth(user, pass)
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("test", "invalid").Code)
+	assert.Equal(t, http.StatusOK, serve("test", "valid").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("test", "invalid").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("test", "invalid").Code)
+
+	w := serve("test", "valid")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve("other", "valid").Code)
+}
+
+func TestDefaultKey(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ip:10.0.0.1", DefaultKey(r))
+
+	r.SetBasicAuth("test", "test")
+	assert.Equal(t, "user:test", DefaultKey(r))
+}
+
+type strategyFunc func(r *http.Request) (auth.Info, error)
+
+func (fn strategyFunc) Authenticate(_ context.Context, r *http.Request) (auth.Info, error) {
+	return fn(r)
+}
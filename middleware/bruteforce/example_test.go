@@ -0,0 +1,33 @@
+package bruteforce_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/middleware/bruteforce"
+)
+
+func ExampleProtector() {
+	p := bruteforce.New(
+		libcache.LRU.New(0),
+		bruteforce.SetMaxAttempts(2),
+		bruteforce.SetLockout(time.Minute),
+	)
+
+	p.RecordFailure("user:example")
+	fmt.Println(p.IsBlocked("user:example"))
+
+	p.RecordFailure("user:example")
+	fmt.Println(p.IsBlocked("user:example"))
+
+	p.Reset("user:example")
+	fmt.Println(p.IsBlocked("user:example"))
+
+	// Output:
+	// false
+	// true
+	// false
+}
@@ -0,0 +1,47 @@
+package bruteforce
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetMaxAttempts sets the number of failed attempts that lock the key.
+// Default 5.
+func SetMaxAttempts(n int) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if p, ok := v.(*Protector); ok {
+			p.maxAttempts = n
+		}
+	})
+}
+
+// SetLockout sets the first lockout duration.
+// Default 1 Minute.
+func SetLockout(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if p, ok := v.(*Protector); ok {
+			p.lockout = d
+		}
+	})
+}
+
+// SetMaxLockout sets the maximum lockout duration.
+// Default 1 Hour.
+func SetMaxLockout(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if p, ok := v.(*Protector); ok {
+			p.maxLockout = d
+		}
+	})
+}
+
+// SetKeyFunc sets the function used by Middleware to derive the identity key.
+// Default DefaultKey.
+func SetKeyFunc(fn KeyFunc) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if p, ok := v.(*Protector); ok {
+			p.key = fn
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package bruteforce
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxAttempts(t *testing.T) {
+	p := New(nil, SetMaxAttempts(3))
+	assert.Equal(t, 3, p.maxAttempts)
+}
+
+func TestSetLockout(t *testing.T) {
+	p := New(nil, SetLockout(time.Second))
+	assert.Equal(t, time.Second, p.lockout)
+}
+
+func TestSetMaxLockout(t *testing.T) {
+	p := New(nil, SetMaxLockout(time.Second))
+	assert.Equal(t, time.Second, p.maxLockout)
+}
+
+func TestSetKeyFunc(t *testing.T) {
+	p := New(nil, SetKeyFunc(func(r *http.Request) string { return "key" }))
+	assert.Equal(t, "key", p.key(nil))
+}
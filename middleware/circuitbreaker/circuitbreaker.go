@@ -0,0 +1,184 @@
+// Package circuitbreaker provides a circuit breaker for strategies that depend on external backends,
+// (e.g LDAP, oauth2 introspection) to fail fast while the backend is unavailable,
+// instead of blocking each request for the full backend timeout.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// ErrServiceUnavailable is returned by CircuitBreaker Authenticate method,
+// when the circuit is open or a half-open probe already in flight.
+var ErrServiceUnavailable = errors.New("circuitbreaker: service unavailable")
+
+// State represents circuit breaker state.
+type State int
+
+const (
+	// Closed state pass requests to the underlying strategy.
+	Closed State = iota
+	// Open state reject requests immediately.
+	Open
+	// HalfOpen state allow a single probe request to test the backend recovery.
+	HalfOpen
+)
+
+// String returns state name.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// CircuitBreaker wraps a strategy and trips to Open state,
+// when the failure rate within the rolling window exceeds the threshold.
+// After the recovery timeout it enters HalfOpen state and allows one probe request,
+// the probe success re-closes the circuit, Otherwise it opens again.
+type CircuitBreaker struct {
+	strategy    auth.Strategy
+	threshold   float64
+	minRequests int
+	window      time.Duration
+	recovery    time.Duration
+	isFailure   func(error) bool
+	mu          sync.Mutex
+	state       State
+	openedAt    time.Time
+	probing     bool
+	outcomes    []outcome
+	now         func() time.Time
+}
+
+// State returns the current circuit state.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.current()
+}
+
+// Authenticate request using the underlying strategy,
+// or return ErrServiceUnavailable while the circuit is open.
+func (cb *CircuitBreaker) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	probe, err := cb.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := cb.strategy.Authenticate(ctx, r)
+	cb.record(probe, err != nil && cb.isFailure(err))
+	return info, err
+}
+
+func (cb *CircuitBreaker) allow() (probe bool, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.current() {
+	case Open:
+		return false, ErrServiceUnavailable
+	case HalfOpen:
+		if cb.probing {
+			return false, ErrServiceUnavailable
+		}
+		cb.state = HalfOpen
+		cb.probing = true
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (cb *CircuitBreaker) record(probe, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+
+	if probe {
+		cb.probing = false
+		cb.outcomes = nil
+		if failed {
+			cb.state = Open
+			cb.openedAt = now
+			return
+		}
+		cb.state = Closed
+		return
+	}
+
+	if cb.state != Closed {
+		return
+	}
+
+	cb.outcomes = append(cb.outcomes, outcome{at: now, failed: failed})
+	cb.prune(now)
+
+	if len(cb.outcomes) < cb.minRequests {
+		return
+	}
+
+	failures := 0
+	for _, o := range cb.outcomes {
+		if o.failed {
+			failures++
+		}
+	}
+
+	if float64(failures)/float64(len(cb.outcomes)) > cb.threshold {
+		cb.state = Open
+		cb.openedAt = now
+		cb.outcomes = nil
+	}
+}
+
+// current return the state, taking into account the recovery timeout.
+// it must be called while holding the lock.
+func (cb *CircuitBreaker) current() State {
+	if cb.state == Open && !cb.now().Before(cb.openedAt.Add(cb.recovery)) {
+		return HalfOpen
+	}
+	return cb.state
+}
+
+func (cb *CircuitBreaker) prune(now time.Time) {
+	i := 0
+	for i < len(cb.outcomes) && !cb.outcomes[i].at.After(now.Add(-cb.window)) {
+		i++
+	}
+	cb.outcomes = cb.outcomes[i:]
+}
+
+// New return new CircuitBreaker wrapping the provided strategy.
+func New(s auth.Strategy, opts ...auth.Option) *CircuitBreaker {
+	cb := new(CircuitBreaker)
+	cb.strategy = s
+	cb.threshold = 0.5
+	cb.minRequests = 10
+	cb.window = time.Minute
+	cb.recovery = time.Second * 30
+	cb.isFailure = func(error) bool { return true }
+	cb.now = time.Now
+
+	for _, opt := range opts {
+		opt.Apply(cb)
+	}
+
+	return cb
+}
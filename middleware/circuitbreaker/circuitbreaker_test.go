@@ -0,0 +1,149 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+var errBackend = errors.New("backend down")
+
+type mockStrategy struct {
+	err   error
+	calls int
+	block chan struct{}
+}
+
+func (m *mockStrategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	m.calls++
+	if m.block != nil {
+		<-m.block
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return auth.NewDefaultUser("test", "1", nil, nil), nil
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m := new(mockStrategy)
+	cb := New(m, SetMinRequests(4), SetThreshold(0.5), SetRecoveryTimeout(time.Second*30))
+	cb.now = func() time.Time { return now }
+
+	authenticate := func() error {
+		_, err := cb.Authenticate(context.TODO(), nil)
+		return err
+	}
+
+	// Round #1 stay closed while failure rate within threshold.
+	assert.NoError(t, authenticate())
+	assert.NoError(t, authenticate())
+	m.err = errBackend
+	assert.Equal(t, errBackend, authenticate())
+	assert.Equal(t, errBackend, authenticate())
+	assert.Equal(t, Closed, cb.State())
+
+	// Round #2 trip to open once failure rate exceeds threshold.
+	assert.Equal(t, errBackend, authenticate())
+	assert.Equal(t, Open, cb.State())
+
+	// Round #3 fail fast without calling the backend while open.
+	calls := m.calls
+	assert.Equal(t, ErrServiceUnavailable, authenticate())
+	assert.Equal(t, calls, m.calls)
+
+	// Round #4 half-open after recovery timeout, failed probe re-opens.
+	now = now.Add(time.Second * 30)
+	assert.Equal(t, HalfOpen, cb.State())
+	assert.Equal(t, errBackend, authenticate())
+	assert.Equal(t, Open, cb.State())
+	assert.Equal(t, calls+1, m.calls)
+
+	// Round #5 successful probe re-closes.
+	now = now.Add(time.Second * 30)
+	m.err = nil
+	assert.NoError(t, authenticate())
+	assert.Equal(t, Closed, cb.State())
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m := &mockStrategy{err: errBackend}
+	cb := New(m, SetMinRequests(1))
+	cb.now = func() time.Time { return now }
+
+	_, _ = cb.Authenticate(context.TODO(), nil)
+	assert.Equal(t, Open, cb.State())
+
+	now = now.Add(time.Minute)
+	m.err = nil
+	m.block = make(chan struct{})
+	done := make(chan error)
+
+	go func() {
+		_, err := cb.Authenticate(context.TODO(), nil)
+		done <- err
+	}()
+
+	// wait until the probe in flight.
+	for {
+		cb.mu.Lock()
+		probing := cb.probing
+		cb.mu.Unlock()
+		if probing {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := cb.Authenticate(context.TODO(), nil)
+	assert.Equal(t, ErrServiceUnavailable, err)
+
+	close(m.block)
+	assert.NoError(t, <-done)
+	assert.Equal(t, Closed, cb.State())
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m := &mockStrategy{err: errBackend}
+	cb := New(m, SetMinRequests(2), SetWindow(time.Minute))
+	cb.now = func() time.Time { return now }
+
+	_, _ = cb.Authenticate(context.TODO(), nil)
+	now = now.Add(time.Minute * 2)
+	_, _ = cb.Authenticate(context.TODO(), nil)
+
+	// first failure falls outside the window.
+	assert.Equal(t, Closed, cb.State())
+	assert.Len(t, cb.outcomes, 1)
+}
+
+func TestCircuitBreakerFailureFunc(t *testing.T) {
+	errInvalid := errors.New("invalid credentials")
+	m := &mockStrategy{err: errInvalid}
+	cb := New(m, SetMinRequests(1), SetFailureFunc(func(err error) bool {
+		return err != errInvalid
+	}))
+
+	for i := 0; i < 5; i++ {
+		_, err := cb.Authenticate(context.TODO(), nil)
+		assert.Equal(t, errInvalid, err)
+	}
+
+	assert.Equal(t, Closed, cb.State())
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "closed", Closed.String())
+	assert.Equal(t, "open", Open.String())
+	assert.Equal(t, "half-open", HalfOpen.String())
+	assert.Equal(t, "unknown", State(10).String())
+}
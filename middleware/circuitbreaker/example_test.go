@@ -0,0 +1,37 @@
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+	"github.com/shaj13/go-guardian/v2/middleware/circuitbreaker"
+)
+
+func Example() {
+	// typically ldap or introspection strategy.
+	backend := basic.New(func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		return nil, errors.New("strategies/ldap: connection timed out")
+	})
+
+	cb := circuitbreaker.New(
+		backend,
+		circuitbreaker.SetMinRequests(1),
+		circuitbreaker.SetRecoveryTimeout(time.Second*30),
+	)
+
+	r, _ := http.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("admin", "admin")
+	_, err := cb.Authenticate(r.Context(), r)
+	fmt.Println(err, cb.State())
+	_, err = cb.Authenticate(r.Context(), r)
+	fmt.Println(err, cb.State())
+
+	// Output:
+	// strategies/ldap: connection timed out open
+	// circuitbreaker: service unavailable open
+}
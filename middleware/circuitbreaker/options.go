@@ -0,0 +1,60 @@
+package circuitbreaker
+
+import (
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetThreshold sets the failure rate, between 0 and 1,
+// that trips the circuit once exceeded.
+// Default 0.5.
+func SetThreshold(rate float64) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if cb, ok := v.(*CircuitBreaker); ok {
+			cb.threshold = rate
+		}
+	})
+}
+
+// SetMinRequests sets the minimum number of requests within the window,
+// before the failure rate evaluated.
+// Default 10.
+func SetMinRequests(n int) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if cb, ok := v.(*CircuitBreaker); ok {
+			cb.minRequests = n
+		}
+	})
+}
+
+// SetWindow sets the rolling window duration used to compute the failure rate.
+// Default 1 Minute.
+func SetWindow(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if cb, ok := v.(*CircuitBreaker); ok {
+			cb.window = d
+		}
+	})
+}
+
+// SetRecoveryTimeout sets the duration the circuit stays open before moving to half-open.
+// Default 30 Seconds.
+func SetRecoveryTimeout(d time.Duration) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if cb, ok := v.(*CircuitBreaker); ok {
+			cb.recovery = d
+		}
+	})
+}
+
+// SetFailureFunc sets the function that reports whether a strategy error counts as a backend failure.
+// Use it to exclude invalid credentials errors from the failure rate.
+// Default all errors count.
+func SetFailureFunc(fn func(error) bool) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if cb, ok := v.(*CircuitBreaker); ok {
+			cb.isFailure = fn
+		}
+	})
+}
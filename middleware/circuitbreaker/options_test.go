@@ -0,0 +1,28 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	errInvalid := errors.New("invalid credentials")
+	cb := New(
+		nil,
+		SetThreshold(0.2),
+		SetMinRequests(3),
+		SetWindow(time.Second),
+		SetRecoveryTimeout(time.Hour),
+		SetFailureFunc(func(err error) bool { return err != errInvalid }),
+	)
+
+	assert.Equal(t, 0.2, cb.threshold)
+	assert.Equal(t, 3, cb.minRequests)
+	assert.Equal(t, time.Second, cb.window)
+	assert.Equal(t, time.Hour, cb.recovery)
+	assert.False(t, cb.isFailure(errInvalid))
+	assert.True(t, cb.isFailure(errors.New("timeout")))
+}
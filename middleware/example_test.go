@@ -0,0 +1,37 @@
+package middleware_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/basic"
+	"github.com/shaj13/go-guardian/v2/middleware"
+)
+
+func ExampleRouteAuth() {
+	strategy := basic.New(func(ctx context.Context, r *http.Request, userName, password string) (auth.Info, error) {
+		if userName == "admin" && password == "admin" {
+			return auth.NewDefaultUser("admin", "1", nil, nil), nil
+		}
+		return nil, fmt.Errorf("Invalid credentials")
+	})
+
+	ra := middleware.NewRouteAuth()
+	ra.Public("/public/")
+	ra.Handle("/admin/", strategy)
+
+	handler := ra.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, path := range []string{"/public/index.html", "/admin/users"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		fmt.Println(path, w.Code)
+	}
+
+	// Output:
+	// /public/index.html 200
+	// /admin/users 401
+}
@@ -0,0 +1,26 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/middleware/health"
+)
+
+func ExampleHandler() {
+	// typically ldap.GetHealthCheckFunc(cfg) or introspection.GetHealthCheckFunc(addr, opts...).
+	ldap := health.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("strategies/ldap: connection refused")
+	})
+
+	h := health.Handler(health.Named("ldap", ldap))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	fmt.Print(w.Code, " ", w.Body.String())
+
+	// Output:
+	// 503 {"status":"unavailable","errors":{"ldap":"strategies/ldap: connection refused"}}
+}
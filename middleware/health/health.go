@@ -0,0 +1,125 @@
+// Package health provides HTTP handler to report whether the backends,
+// used by the authentication strategies (e.g LDAP, oauth2 introspection, JWKS) are reachable.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// StatusOK reported when all checkers pass.
+	StatusOK = "ok"
+	// StatusUnavailable reported when any checker fails.
+	StatusUnavailable = "unavailable"
+	// DefaultTimeout is the checkers deadline used by Handler.
+	DefaultTimeout = time.Second * 5
+)
+
+// Checker checks a backend health.
+type Checker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as Checker,
+// e.g the strategies GetHealthCheckFunc.
+type CheckerFunc func(ctx context.Context) error
+
+// HealthCheck calls fn(ctx).
+func (fn CheckerFunc) HealthCheck(ctx context.Context) error {
+	return fn(ctx)
+}
+
+type named struct {
+	Checker
+	name string
+}
+
+// Named return checker reported under name in the handler response.
+func Named(name string, c Checker) Checker {
+	return named{Checker: c, name: name}
+}
+
+// Response represents the health handler response body.
+type Response struct {
+	Status string            `json:"status"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Handler return HTTP handler that run the checkers concurrently,
+// and reply with 200 OK when all pass, Otherwise 503 Service Unavailable with per-checker errors.
+// Unnamed checkers reported by their index.
+// Handler is similar to HandlerWithTimeout(DefaultTimeout, checkers...).
+func Handler(checkers ...Checker) http.Handler {
+	return HandlerWithTimeout(DefaultTimeout, checkers...)
+}
+
+// HandlerWithTimeout return HTTP handler similar to Handler,
+// that give the checkers up to timeout to complete,
+// checkers not completed by the deadline reported as failed with the context error.
+func HandlerWithTimeout(timeout time.Duration, checkers ...Checker) http.Handler {
+	type result struct {
+		index int
+		err   error
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		resp := Response{Status: StatusOK}
+		results := make(chan result, len(checkers))
+		names := make([]string, len(checkers))
+
+		for i, c := range checkers {
+			names[i] = strconv.Itoa(i)
+			if n, ok := c.(named); ok {
+				names[i] = n.name
+			}
+
+			go func(i int, c Checker) {
+				results <- result{index: i, err: c.HealthCheck(ctx)}
+			}(i, c)
+		}
+
+		fail := func(i int, err error) {
+			if resp.Errors == nil {
+				resp.Errors = make(map[string]string)
+			}
+			resp.Errors[names[i]] = err.Error()
+		}
+
+		done := make([]bool, len(checkers))
+
+	wait:
+		for n := 0; n < len(checkers); n++ {
+			select {
+			case res := <-results:
+				done[res.index] = true
+				if res.err != nil {
+					fail(res.index, res.err)
+				}
+			case <-ctx.Done():
+				for i := range checkers {
+					if !done[i] {
+						fail(i, ctx.Err())
+					}
+				}
+				break wait
+			}
+		}
+
+		code := http.StatusOK
+		if len(resp.Errors) > 0 {
+			resp.Status = StatusUnavailable
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	ok := CheckerFunc(func(ctx context.Context) error { return nil })
+	fail := CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+
+	table := []struct {
+		name     string
+		checkers []Checker
+		code     int
+		body     string
+	}{
+		{
+			name:     "it return ok when all checkers pass",
+			checkers: []Checker{Named("ldap", ok), ok},
+			code:     http.StatusOK,
+			body:     `{"status":"ok"}`,
+		},
+		{
+			name:     "it return ok without checkers",
+			checkers: nil,
+			code:     http.StatusOK,
+			body:     `{"status":"ok"}`,
+		},
+		{
+			name:     "it return per checker errors when any fail",
+			checkers: []Checker{Named("ldap", fail), Named("introspection", ok), fail},
+			code:     http.StatusServiceUnavailable,
+			body:     `{"status":"unavailable","errors":{"2":"connection refused","ldap":"connection refused"}}`,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/healthz", nil)
+			Handler(tt.checkers...).ServeHTTP(w, r)
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestHandlerStrategyBackend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	check := CheckerFunc(func(ctx context.Context) error {
+		req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	})
+	h := Handler(Named("backend", check))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	srv.Close()
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"backend"`)
+}
+
+func TestHandlerWithTimeout(t *testing.T) {
+	ok := CheckerFunc(func(ctx context.Context) error { return nil })
+	hang := CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond * 100)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	start := time.Now()
+	HandlerWithTimeout(time.Millisecond*20, Named("ldap", hang), Named("jwks", ok)).
+		ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+
+	assert.Less(t, int64(time.Since(start)), int64(time.Millisecond*100))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"unavailable","errors":{"ldap":"context deadline exceeded"}}`, w.Body.String())
+}
@@ -0,0 +1,23 @@
+package ipfilter_test
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/shaj13/go-guardian/v2/middleware/ipfilter"
+)
+
+func ExampleFilter() {
+	f := ipfilter.New(ipfilter.SetTrustDepth(1))
+	_ = f.AllowCIDR("10.0.0.0/8")
+	_ = f.BlockCIDR("10.0.0.13")
+
+	fmt.Println(f.Allowed(net.ParseIP("10.0.0.1")))
+	fmt.Println(f.Allowed(net.ParseIP("10.0.0.13")))
+	fmt.Println(f.Allowed(net.ParseIP("192.168.0.1")))
+
+	// Output:
+	// true
+	// false
+	// false
+}
@@ -0,0 +1,143 @@
+// Package ipfilter provides HTTP middleware to allow or block requests by the client IP,
+// using CIDR ranges that can be modified at runtime.
+package ipfilter
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// Filter allow or block client IPs based on allowed and blocked CIDR ranges.
+// Blocked ranges checked first, then allowed ranges,
+// an empty allow list allow all IPs that are not blocked.
+type Filter struct {
+	mu         sync.RWMutex
+	allowed    map[string]*net.IPNet
+	blocked    map[string]*net.IPNet
+	trustDepth int
+}
+
+// AllowCIDR adds the CIDR range (or single IP) to the allow list.
+func (f *Filter) AllowCIDR(cidr string) error {
+	return f.add(f.allowed, cidr)
+}
+
+// BlockCIDR adds the CIDR range (or single IP) to the block list.
+func (f *Filter) BlockCIDR(cidr string) error {
+	return f.add(f.blocked, cidr)
+}
+
+// RemoveCIDR removes the CIDR range (or single IP) from both allow and block lists.
+func (f *Filter) RemoveCIDR(cidr string) {
+	_, n, err := parseCIDR(cidr)
+	if err != nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.allowed, n.String())
+	delete(f.blocked, n.String())
+}
+
+// Allowed reports whether the ip allowed.
+func (f *Filter) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if contains(f.blocked, ip) {
+		return false
+	}
+
+	return len(f.allowed) == 0 || contains(f.allowed, ip)
+}
+
+// ClientIP returns the request client IP, or nil if it can not be parsed.
+// When trust depth is greater than zero, the client IP read from X-Forwarded-For header,
+// skipping the addresses appended by the trusted proxies.
+// The remote address used when X-Forwarded-For has fewer entries than the trust depth,
+// since the remaining entries are client controlled.
+func (f *Filter) ClientIP(r *http.Request) net.IP {
+	if f.trustDepth > 0 {
+		if xff := r.Header.Get("X-Forwarded-For"); len(xff) > 0 {
+			ips := strings.Split(xff, ",")
+			if i := len(ips) - f.trustDepth; i >= 0 {
+				return net.ParseIP(strings.TrimSpace(ips[i]))
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return net.ParseIP(host)
+}
+
+// Middleware return HTTP handler, that reply with 403 Forbidden,
+// when the request client IP is not allowed.
+func (f *Filter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.Allowed(f.ClientIP(r)) {
+			code := http.StatusForbidden
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *Filter) add(list map[string]*net.IPNet, cidr string) error {
+	_, n, err := parseCIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	list[n.String()] = n
+	return nil
+}
+
+func contains(list map[string]*net.IPNet, ip net.IP) bool {
+	for _, n := range list {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCIDR parse CIDR notation or a single IP as a full length mask range.
+func parseCIDR(s string) (net.IP, *net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		if ip := net.ParseIP(s); ip != nil {
+			bits := 128
+			if ip.To4() != nil {
+				bits = 32
+			}
+			return ip, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+		}
+	}
+	return net.ParseCIDR(s)
+}
+
+// New return new Filter, with empty allow and block lists.
+func New(opts ...auth.Option) *Filter {
+	f := new(Filter)
+	f.allowed = make(map[string]*net.IPNet)
+	f.blocked = make(map[string]*net.IPNet)
+	for _, opt := range opts {
+		opt.Apply(f)
+	}
+	return f
+}
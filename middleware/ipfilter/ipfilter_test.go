@@ -0,0 +1,140 @@
+package ipfilter
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterAllowed(t *testing.T) {
+	f := New()
+	assert.NoError(t, f.AllowCIDR("10.0.0.0/8"))
+	assert.NoError(t, f.AllowCIDR("2001:db8::/32"))
+	assert.NoError(t, f.BlockCIDR("10.1.0.0/16"))
+	assert.NoError(t, f.BlockCIDR("2001:db8::1"))
+	assert.Error(t, f.AllowCIDR("invalid"))
+
+	table := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "10.0.0.1", expected: true},
+		{ip: "10.1.0.1", expected: false},
+		{ip: "192.168.0.1", expected: false},
+		{ip: "2001:db8::2", expected: true},
+		{ip: "2001:db8::1", expected: false},
+		{ip: "2001:db9::1", expected: false},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.expected, f.Allowed(net.ParseIP(tt.ip)))
+		})
+	}
+
+	assert.False(t, f.Allowed(nil))
+}
+
+func TestFilterRuntimeChanges(t *testing.T) {
+	f := New()
+	ip := net.ParseIP("192.168.1.10")
+
+	// Round #1 empty lists allow all.
+	assert.True(t, f.Allowed(ip))
+
+	// Round #2 block at runtime.
+	assert.NoError(t, f.BlockCIDR("192.168.1.0/24"))
+	assert.False(t, f.Allowed(ip))
+
+	// Round #3 remove at runtime, allow list restrict other IPs.
+	f.RemoveCIDR("192.168.1.0/24")
+	assert.NoError(t, f.AllowCIDR("192.168.1.10"))
+	assert.True(t, f.Allowed(ip))
+	assert.False(t, f.Allowed(net.ParseIP("192.168.1.11")))
+}
+
+func TestFilterClientIP(t *testing.T) {
+	table := []struct {
+		name     string
+		depth    int
+		xff      string
+		expected string
+	}{
+		{
+			name:     "it return remote addr when trust depth zero",
+			xff:      "1.1.1.1",
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "it return remote addr when xff missing",
+			depth:    1,
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "it return xff last entry when trust depth one",
+			depth:    1,
+			xff:      "6.6.6.6, 1.1.1.1",
+			expected: "1.1.1.1",
+		},
+		{
+			name:     "it skip trusted proxies entries",
+			depth:    2,
+			xff:      "6.6.6.6, 1.1.1.1, 172.16.0.1",
+			expected: "1.1.1.1",
+		},
+		{
+			name:     "it return remote addr when xff shorter than trust depth",
+			depth:    3,
+			xff:      "1.1.1.1, 172.16.0.1",
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "it return nil when xff entry invalid",
+			depth:    1,
+			xff:      "1.1.1.1, not-an-ip",
+			expected: "<nil>",
+		},
+		{
+			name:     "it parse ipv6 entries",
+			depth:    1,
+			xff:      "2001:db8::1",
+			expected: "2001:db8::1",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			f := New(SetTrustDepth(tt.depth))
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			if len(tt.xff) > 0 {
+				r.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			assert.Equal(t, tt.expected, f.ClientIP(r).String())
+		})
+	}
+}
+
+func TestFilterMiddleware(t *testing.T) {
+	f := New(SetTrustDepth(1))
+	assert.NoError(t, f.BlockCIDR("6.6.6.0/24"))
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := f.Middleware(next)
+
+	serve := func(xff string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("X-Forwarded-For", xff)
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("1.1.1.1"))
+	assert.Equal(t, http.StatusForbidden, serve("6.6.6.6"))
+	assert.Equal(t, http.StatusForbidden, serve("garbage"))
+}
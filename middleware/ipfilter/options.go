@@ -0,0 +1,16 @@
+package ipfilter
+
+import (
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetTrustDepth sets the number of trusted reverse proxies in front of the server,
+// to read the client IP from X-Forwarded-For header.
+// Default 0, the client IP read from the request remote address.
+func SetTrustDepth(n int) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if f, ok := v.(*Filter); ok {
+			f.trustDepth = n
+		}
+	})
+}
@@ -0,0 +1,12 @@
+package ipfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTrustDepth(t *testing.T) {
+	f := New(SetTrustDepth(2))
+	assert.Equal(t, 2, f.trustDepth)
+}
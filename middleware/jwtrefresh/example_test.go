@@ -0,0 +1,45 @@
+package jwtrefresh_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/jwt"
+	"github.com/shaj13/go-guardian/v2/middleware/jwtrefresh"
+)
+
+func Example() {
+	keeper := jwt.StaticSecret{
+		ID:        "id",
+		Secret:    []byte("secret"),
+		Algorithm: jwt.HS256,
+	}
+	strategy := jwt.New(libcache.LRU.New(0), keeper)
+
+	issue := func(info auth.Info, c claims.Standard) (string, error) {
+		return jwt.IssueAccessToken(info, keeper, jwt.SetExpDuration(time.Hour))
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := jwtrefresh.Middleware(strategy, time.Minute*5, issue, next)
+
+	info := auth.NewDefaultUser("example", "1", nil, nil)
+	tk, _ := jwt.IssueAccessToken(info, keeper, jwt.SetExpDuration(time.Minute*2))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+tk)
+	h.ServeHTTP(w, r)
+
+	fmt.Println(w.Code, len(w.Header().Get(jwtrefresh.Header)) > 0)
+
+	// Output:
+	// 200 true
+}
@@ -0,0 +1,82 @@
+// Package jwtrefresh provides HTTP middleware that transparently renews expiring jwt tokens,
+// so clients can update their stored token without being logged out.
+package jwtrefresh
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/jwt"
+)
+
+// Header is the response header where the refreshed token set.
+const Header = "X-Refreshed-Token"
+
+// IssueFunc declare function signature to issue a refreshed token,
+// from the authenticated user info and the existing token claims,
+// e.g using jwt.IssueAccessToken.
+type IssueFunc func(info auth.Info, c claims.Standard) (string, error)
+
+type refresher struct {
+	strategy auth.Strategy
+	leeway   time.Duration
+	issue    IssueFunc
+	now      func() time.Time
+}
+
+func (rf *refresher) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, err := rf.strategy.Authenticate(r.Context(), r)
+		if err != nil {
+			code := http.StatusUnauthorized
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		if tk, ok := rf.refresh(info); ok {
+			w.Header().Set(Header, tk)
+		}
+
+		next.ServeHTTP(w, auth.RequestWithUser(info, r))
+	})
+}
+
+// refresh issue new token if the verified token expires within the leeway.
+// The claims read from info as stored by the jwt strategy,
+// hence info authenticated by other strategies never refreshed.
+func (rf *refresher) refresh(info auth.Info) (string, bool) {
+	c, ok := jwt.GetClaims(info)
+	if !ok || c.ExpiresAt == nil || c.Subject != info.GetID() {
+		return "", false
+	}
+
+	if time.Time(*c.ExpiresAt).Sub(rf.now()) > rf.leeway {
+		return "", false
+	}
+
+	tk, err := rf.issue(info, c)
+	if err != nil {
+		return "", false
+	}
+
+	return tk, true
+}
+
+// Middleware return HTTP handler, that authenticate requests using the jwt strategy,
+// and reply with 401 Unauthorized when authentication fails e.g expired token.
+// When the token expires within the refresh leeway a refreshed token issued
+// and set in the X-Refreshed-Token response header, before invoking next.
+// Only users authenticated by the jwt strategy refreshed, when s combines other strategies
+// (e.g union) users authenticated by them served without refresh.
+// A failure to issue the refreshed token does not fail the request.
+func Middleware(s auth.Strategy, refreshLeeway time.Duration, issue IssueFunc, next http.Handler) http.Handler {
+	rf := &refresher{
+		strategy: s,
+		leeway:   refreshLeeway,
+		issue:    issue,
+		now:      time.Now,
+	}
+	return rf.handler(next)
+}
@@ -0,0 +1,140 @@
+package jwtrefresh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/claims"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/jwt"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/union"
+)
+
+func TestMiddleware(t *testing.T) {
+	keeper := jwt.StaticSecret{
+		ID:        "id",
+		Secret:    []byte("secret"),
+		Algorithm: jwt.HS256,
+	}
+	info := auth.NewDefaultUser("test", "1", nil, nil)
+
+	table := []struct {
+		name          string
+		remaining     time.Duration
+		issueErr      error
+		code          int
+		expectRefresh bool
+	}{
+		{
+			name:          "it refresh token expiring within leeway",
+			remaining:     time.Minute * 2,
+			code:          http.StatusOK,
+			expectRefresh: true,
+		},
+		{
+			name:      "it does not refresh token outside leeway",
+			remaining: time.Minute * 10,
+			code:      http.StatusOK,
+		},
+		{
+			name:      "it reject expired token without refresh",
+			remaining: -time.Minute * 2,
+			code:      http.StatusUnauthorized,
+		},
+		{
+			name:      "it serve request when issue refreshed token fails",
+			remaining: time.Minute * 2,
+			issueErr:  errors.New("issue failed"),
+			code:      http.StatusOK,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			issued := 0
+			issue := func(i auth.Info, c claims.Standard) (string, error) {
+				issued++
+				assert.Equal(t, "1", c.Subject)
+				if tt.issueErr != nil {
+					return "", tt.issueErr
+				}
+				return jwt.IssueAccessToken(i, keeper, jwt.SetExpDuration(time.Hour))
+			}
+
+			tk, err := jwt.IssueAccessToken(info, keeper, jwt.SetExpDuration(tt.remaining))
+			assert.NoError(t, err)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "test", auth.User(r).GetUserName())
+			})
+
+			s := jwt.New(libcache.LRU.New(0), keeper)
+			h := Middleware(s, time.Minute*5, issue, next)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", "Bearer "+tk)
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.code, w.Code)
+			refreshed := w.Header().Get(Header)
+			assert.Equal(t, tt.expectRefresh, len(refreshed) > 0)
+			if tt.code == http.StatusUnauthorized {
+				assert.Equal(t, 0, issued)
+			}
+
+			if tt.expectRefresh {
+				r.Header.Set("Authorization", "Bearer "+refreshed)
+				_, err := s.Authenticate(r.Context(), r)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMiddlewareNonJWTStrategy(t *testing.T) {
+	keeper := jwt.StaticSecret{
+		ID:        "id",
+		Secret:    []byte("secret"),
+		Algorithm: jwt.HS256,
+	}
+	forger := jwt.StaticSecret{
+		ID:        "id",
+		Secret:    []byte("forged"),
+		Algorithm: jwt.HS256,
+	}
+	victim := auth.NewDefaultUser("admin", "0", nil, nil)
+	forged, err := jwt.IssueAccessToken(victim, forger, jwt.SetExpDuration(time.Minute))
+	assert.NoError(t, err)
+
+	s := union.New(jwt.New(libcache.LRU.New(0), keeper), staticStrategy{})
+
+	issue := func(i auth.Info, c claims.Standard) (string, error) {
+		t.Errorf("unexpected refresh for %s", c.Subject)
+		return jwt.IssueAccessToken(i, keeper)
+	}
+
+	h := Middleware(s, time.Minute*5, issue, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer "+forged)
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(Header))
+}
+
+type staticStrategy struct{}
+
+func (staticStrategy) Authenticate(ctx context.Context, r *http.Request) (auth.Info, error) {
+	return auth.NewDefaultUser("test", "1", nil, nil), nil
+}
@@ -0,0 +1,187 @@
+// Package middleware provides net/http middleware to authenticate requests using strategies.
+// The middleware signature func(http.Handler) http.Handler is compatible,
+// with routers that accept standard middleware e.g chi r.Use and r.With.
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/middleware/responder"
+)
+
+type config struct {
+	responder  responder.Responder
+	exemptions []string
+}
+
+func newConfig(opts ...auth.Option) *config {
+	c := new(config)
+	c.responder = responder.TextResponder{}
+	for _, opt := range opts {
+		opt.Apply(c)
+	}
+	return c
+}
+
+// Authenticate return middleware that authenticate requests using the strategy,
+// and stores the user info in the request context, Otherwise reply with 401 Unauthorized.
+func Authenticate(s auth.Strategy, opts ...auth.Option) func(http.Handler) http.Handler {
+	c := newConfig(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.serve(s, next, w, canonical(r))
+		})
+	}
+}
+
+func (c *config) exempt(p string) bool {
+	p = cleanPath(p)
+
+	for _, pattern := range c.exemptions {
+		if strings.HasSuffix(pattern, "*") || strings.HasSuffix(pattern, "/") {
+			// match the prefix on a path segment boundary only.
+			prefix := strings.TrimSuffix(strings.TrimSuffix(pattern, "*"), "/")
+			if p == prefix || strings.HasPrefix(p, prefix+"/") {
+				return true
+			}
+			continue
+		}
+
+		if ok, err := path.Match(pattern, p); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanPath return the canonical path, to prevent dot segments e.g "/public/../admin",
+// from matching exemptions or routes they does not belong to.
+func cleanPath(p string) string {
+	if len(p) == 0 || p[0] != '/' {
+		p = "/" + p
+	}
+	return path.Clean(p)
+}
+
+// canonical return r with its URL path cleaned, keeping a trailing slash,
+// hence the next handler and routers that does not clean paths, e.g chi,
+// route the same path matched against the exemptions and routes.
+func canonical(r *http.Request) *http.Request {
+	p := cleanPath(r.URL.Path)
+	if strings.HasSuffix(r.URL.Path, "/") && p != "/" {
+		p += "/"
+	}
+
+	if p == r.URL.Path {
+		return r
+	}
+
+	u := *r.URL
+	u.Path = p
+	u.RawPath = ""
+
+	r = r.WithContext(r.Context())
+	r.URL = &u
+
+	return r
+}
+
+func (c *config) serve(s auth.Strategy, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	if c.exempt(r.URL.Path) {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	info, err := s.Authenticate(r.Context(), r)
+	if err != nil {
+		c.responder.Respond(w, r, http.StatusUnauthorized, err)
+		return
+	}
+	next.ServeHTTP(w, auth.RequestWithUser(info, r))
+}
+
+type route struct {
+	pattern  string
+	strategy auth.Strategy
+}
+
+func (rt route) match(p string) bool {
+	if strings.HasSuffix(rt.pattern, "/") {
+		// p is clean, with no trailing slash.
+		return strings.HasPrefix(p+"/", rt.pattern)
+	}
+	ok, err := path.Match(rt.pattern, p)
+	return err == nil && ok
+}
+
+// RouteAuth maps request path patterns to strategies,
+// allowing public routes to skip authentication,
+// and different routes to require different strategies.
+//
+// Patterns use path.Match syntax, a pattern ending with a slash matches the whole subtree,
+// similar to http.ServeMux. The request path cleaned before matching,
+// and the next handler receives the request with the cleaned path,
+// routes matched in the order they were added,
+// and requests that does not match any route are rejected with 401 Unauthorized.
+type RouteAuth struct {
+	mu     sync.RWMutex
+	routes []route
+	config *config
+}
+
+// Handle authenticate requests with path matching pattern using s.
+func (ra *RouteAuth) Handle(pattern string, s auth.Strategy) {
+	ra.mu.Lock()
+	defer ra.mu.Unlock()
+	ra.routes = append(ra.routes, route{pattern: pattern, strategy: s})
+}
+
+// Public mark requests with path matching pattern as public,
+// and pass them to the next handler without authentication.
+func (ra *RouteAuth) Public(pattern string) {
+	ra.Handle(pattern, nil)
+}
+
+// Middleware return HTTP handler, that authenticate requests using the strategy of the matched route.
+func (ra *RouteAuth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = canonical(r)
+		rt, ok := ra.lookup(r.URL.Path)
+
+		switch {
+		case ra.config.exempt(r.URL.Path):
+			next.ServeHTTP(w, r)
+		case !ok:
+			ra.config.responder.Respond(w, r, http.StatusUnauthorized, nil)
+		case rt.strategy == nil:
+			next.ServeHTTP(w, r)
+		default:
+			ra.config.serve(rt.strategy, next, w, r)
+		}
+	})
+}
+
+func (ra *RouteAuth) lookup(p string) (route, bool) {
+	ra.mu.RLock()
+	defer ra.mu.RUnlock()
+
+	p = cleanPath(p)
+	for _, rt := range ra.routes {
+		if rt.match(p) {
+			return rt, true
+		}
+	}
+
+	return route{}, false
+}
+
+// NewRouteAuth return new RouteAuth without routes, which reject all requests.
+func NewRouteAuth(opts ...auth.Option) *RouteAuth {
+	return &RouteAuth{
+		config: newConfig(opts...),
+	}
+}
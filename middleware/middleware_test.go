@@ -0,0 +1,216 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/middleware/responder"
+)
+
+func TestAuthenticate(t *testing.T) {
+	table := []struct {
+		name     string
+		strategy auth.Strategy
+		code     int
+	}{
+		{
+			name:     "it call next handler with user info when authenticated",
+			strategy: strategyFor("user"),
+			code:     http.StatusOK,
+		},
+		{
+			name:     "it return 401 when authentication fails",
+			strategy: strategyFor(""),
+			code:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			Authenticate(tt.strategy)(echoUser()).ServeHTTP(w, r)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Equal(t, "user", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouteAuth(t *testing.T) {
+	ra := NewRouteAuth()
+	ra.Public("/healthz")
+	ra.Public("/public/")
+	ra.Handle("/admin/", strategyFor("admin"))
+	ra.Handle("/api/*", strategyFor("user"))
+	ra.Handle("/denied", strategyFor(""))
+
+	h := ra.Middleware(echoUser())
+
+	table := []struct {
+		path string
+		code int
+		body string
+	}{
+		{path: "/healthz", code: http.StatusOK, body: "anonymous"},
+		{path: "/public/assets/app.js", code: http.StatusOK, body: "anonymous"},
+		{path: "/admin/users/1", code: http.StatusOK, body: "admin"},
+		{path: "/api/books", code: http.StatusOK, body: "user"},
+		{path: "/api/books/1", code: http.StatusUnauthorized},
+		{path: "/denied", code: http.StatusUnauthorized},
+		{path: "/unknown", code: http.StatusUnauthorized},
+		{path: "/public/../denied", code: http.StatusUnauthorized},
+		{path: "/healthz/../admin/users", code: http.StatusOK, body: "admin"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tt.path, nil)
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+type strategyFor string
+
+func (s strategyFor) Authenticate(_ context.Context, _ *http.Request) (auth.Info, error) {
+	if len(s) == 0 {
+		return nil, errors.New("invalid credentials")
+	}
+	return auth.NewDefaultUser(string(s), "1", nil, nil), nil
+}
+
+func echoUser() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info := auth.User(r); info != nil {
+			_, _ = w.Write([]byte(info.GetUserName()))
+			return
+		}
+		_, _ = w.Write([]byte("anonymous"))
+	})
+}
+
+func TestResponder(t *testing.T) {
+	rfc := SetResponder(responder.RFC7235Responder{Challenge: `Bearer realm="api"`})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	Authenticate(strategyFor(""), rfc)(echoUser()).ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="api"`, w.Header().Get("WWW-Authenticate"))
+	assert.Empty(t, w.Body.String())
+
+	ra := NewRouteAuth(SetResponder(responder.JSONResponder{}))
+	ra.Handle("/api/", strategyFor(""))
+
+	for _, p := range []string{"/api/books", "/unknown"} {
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest("GET", p, nil)
+		ra.Middleware(echoUser()).ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Equal(t, `{"code":"UNAUTHORIZED","message":"Unauthorized"}`+"\n", w.Body.String())
+	}
+}
+
+func TestExemptions(t *testing.T) {
+	exemptions := SetExemptions("/healthz", "/metrics*", "/static/")
+
+	ra := NewRouteAuth(exemptions)
+	ra.Handle("/", strategyFor(""))
+
+	handlers := map[string]http.Handler{
+		"Authenticate": Authenticate(strategyFor(""), exemptions)(echoUser()),
+		"RouteAuth":    ra.Middleware(echoUser()),
+	}
+
+	table := []struct {
+		path string
+		code int
+	}{
+		{path: "/healthz", code: http.StatusOK},
+		{path: "/healthz/deep", code: http.StatusUnauthorized},
+		{path: "/metrics", code: http.StatusOK},
+		{path: "/metrics/go/gc", code: http.StatusOK},
+		{path: "/static/css/app.css", code: http.StatusOK},
+		{path: "/api/v1/users", code: http.StatusUnauthorized},
+		{path: "/metrics-admin", code: http.StatusUnauthorized},
+		{path: "/staticfiles", code: http.StatusUnauthorized},
+		{path: "/healthz/../admin", code: http.StatusUnauthorized},
+		{path: "/static/../api/v1/users", code: http.StatusUnauthorized},
+		{path: "/metrics/../../admin", code: http.StatusUnauthorized},
+	}
+
+	for name, h := range handlers {
+		for _, tt := range table {
+			t.Run(name+tt.path, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", tt.path, nil)
+				h.ServeHTTP(w, r)
+				assert.Equal(t, tt.code, w.Code)
+			})
+		}
+	}
+}
+
+func TestNonCleaningRouter(t *testing.T) {
+	// router is similar to routers that does not clean paths, e.g chi.
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/admin/"):
+			if auth.User(r) == nil {
+				_, _ = w.Write([]byte("admin bypassed"))
+				return
+			}
+			_, _ = w.Write([]byte("admin"))
+		case r.URL.Path == "/healthz":
+			_, _ = w.Write([]byte("healthz"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	ra := NewRouteAuth()
+	ra.Public("/healthz")
+	ra.Handle("/admin/", strategyFor("admin"))
+
+	handlers := map[string]http.Handler{
+		"Authenticate": Authenticate(strategyFor("admin"), SetExemptions("/healthz"))(router),
+		"RouteAuth":    ra.Middleware(router),
+	}
+
+	table := []struct {
+		path string
+		body string
+	}{
+		{path: "/admin/../healthz", body: "healthz"},
+		{path: "/admin/x/../../healthz", body: "healthz"},
+		{path: "/healthz/../admin/users", body: "admin"},
+		{path: "//admin/users/", body: "admin"},
+	}
+
+	for name, h := range handlers {
+		for _, tt := range table {
+			t.Run(name+tt.path, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("GET", "/", nil)
+				r.URL.Path = tt.path
+				h.ServeHTTP(w, r)
+				assert.Equal(t, tt.body, w.Body.String())
+			})
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package mutual_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/client"
+	"github.com/shaj13/go-guardian/v2/auth/strategies/token"
+	"github.com/shaj13/go-guardian/v2/middleware/mutual"
+)
+
+func Example() {
+	secret := []byte("shared-secret")
+	strategy := token.NewStatic(map[string]auth.Info{
+		"token": auth.NewDefaultUser("example", "1", nil, nil),
+	})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("balance: 100"))
+	})
+	h := mutual.MutualAuth(mutual.HMACSigner(secret), strategy, next)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/balance", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set(client.NonceHeader, "random-nonce")
+	h.ServeHTTP(w, r)
+
+	// client side, http.Client sets the response request.
+	resp := w.Result()
+	resp.Request = r
+	err := client.VerifyResponse(resp, secret)
+	fmt.Println(w.Code, err)
+
+	// Output:
+	// 200 <nil>
+}
@@ -0,0 +1,121 @@
+// Package mutual provides HTTP middleware for mutual authentication,
+// the client authenticated to the server using a strategy,
+// and the server authenticate itself to the client by signing the response body.
+//
+// The signature covers the response status and body, bound to the request method,
+// target and the client nonce from the X-Request-Nonce header, see client.SignatureBase.
+// It's set in the Server-Authorization response header:
+//
+// 		Server-Authorization: Signature <base64 signature>
+//
+// Clients verify the header using the auth/client package.
+package mutual
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/client"
+	"github.com/shaj13/go-guardian/v2/middleware"
+)
+
+const (
+	// Header is the response header where the server signature set.
+	Header = "Server-Authorization"
+	// Scheme is the Server-Authorization header scheme.
+	Scheme = "Signature"
+)
+
+// HMACSigner implements crypto.Signer using HMAC-SHA256 with the shared secret,
+// for clients and servers that shares a secret instead of a key pair.
+type HMACSigner []byte
+
+// Public return nil, HMAC has no public key.
+func (h HMACSigner) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign return HMAC-SHA256 of digest.
+func (h HMACSigner) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	mac := hmac.New(sha256.New, h)
+	_, _ = mac.Write(digest)
+	return mac.Sum(nil), nil
+}
+
+// Sign return base64 signature of the response to r using key,
+// over the SHA-256 digest of the signature base, see client.SignatureBase.
+// Ed25519 keys sign the digest itself as message.
+func Sign(key crypto.Signer, r *http.Request, status int, body []byte) (string, error) {
+	var opts crypto.SignerOpts = crypto.SHA256
+	if _, ok := key.Public().(ed25519.PublicKey); ok {
+		opts = crypto.Hash(0)
+	}
+
+	base := client.SignatureBase(status, r.Method, r.URL.RequestURI(), r.Header.Get(client.NonceHeader), body)
+	sum := sha256.Sum256(base)
+	sig, err := key.Sign(rand.Reader, sum[:], opts)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// MutualAuth return HTTP handler, that authenticate requests using the strategy as middleware.Authenticate,
+// and sign every response, including authentication failures, using the server key.
+// The key can be ECDSA, Ed25519 or RSA private key, or HMACSigner.
+// Responses are buffered until next returns to sign the full body,
+// and replied with 500 Internal Server Error when signing fails.
+// opts are middleware options, e.g middleware.SetResponder.
+func MutualAuth(key crypto.Signer, s auth.Strategy, next http.Handler, opts ...auth.Option) http.Handler {
+	h := middleware.Authenticate(s, opts...)(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bw := &bufferedWriter{ResponseWriter: w, code: http.StatusOK}
+		h.ServeHTTP(bw, r)
+
+		sig, err := Sign(key, r, bw.code, bw.body.Bytes())
+		if err != nil {
+			// drop the handler headers, to not leak them with the error.
+			for k := range w.Header() {
+				delete(w.Header(), k)
+			}
+			code := http.StatusInternalServerError
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		w.Header().Set(Header, Scheme+" "+sig)
+		w.WriteHeader(bw.code)
+		_, _ = w.Write(bw.body.Bytes())
+	})
+}
+
+// bufferedWriter buffer the response status and body,
+// headers written directly to the underlying writer.
+type bufferedWriter struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *bufferedWriter) WriteHeader(code int) {
+	if b.wroteHeader {
+		return
+	}
+	b.wroteHeader = true
+	b.code = code
+}
+
+func (b *bufferedWriter) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
@@ -0,0 +1,146 @@
+package mutual
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/auth/client"
+)
+
+func TestMutualAuth(t *testing.T) {
+	ecc, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edpub, edpriv, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	keys := []struct {
+		name   string
+		signer crypto.Signer
+		public crypto.PublicKey
+	}{
+		{name: "ECDSA", signer: ecc, public: &ecc.PublicKey},
+		{name: "Ed25519", signer: edpriv, public: edpub},
+		{name: "RSA", signer: rsaKey, public: &rsaKey.PublicKey},
+		{name: "HMAC", signer: HMACSigner("secret"), public: []byte("secret")},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"user":"` + auth.User(r).GetUserName() + `"}`))
+	})
+
+	for _, k := range keys {
+		t.Run(k.name, func(t *testing.T) {
+			h := MutualAuth(k.signer, strategy("user"), next)
+			serve := func(target, token, nonce string) (*httptest.ResponseRecorder, *http.Request) {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest("POST", target, nil)
+				r.Header.Set(client.NonceHeader, nonce)
+				if len(token) > 0 {
+					r.Header.Set("Authorization", "Bearer "+token)
+				}
+				h.ServeHTTP(w, r)
+				return w, r
+			}
+			verify := func(w *httptest.ResponseRecorder, r *http.Request) error {
+				resp := &http.Response{
+					StatusCode: w.Code,
+					Header:     w.Header(),
+					Body:       ioutil.NopCloser(bytes.NewReader(w.Body.Bytes())),
+					Request:    r,
+				}
+				return client.VerifyResponse(resp, k.public)
+			}
+
+			// authenticated request.
+			w, r := serve("/transfers?to=alice", "user", "nonce-1")
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.True(t, strings.HasPrefix(w.Header().Get(Header), Scheme+" "))
+			assert.Equal(t, `{"user":"user"}`, w.Body.String())
+			assert.NoError(t, verify(w, r))
+
+			// bit flip in response body.
+			flipped := httptest.NewRecorder()
+			for k, v := range w.Header() {
+				flipped.Header()[k] = v
+			}
+			flipped.Code = w.Code
+			body := append([]byte{}, w.Body.Bytes()...)
+			body[len(body)/2] ^= 1
+			flipped.Body = bytes.NewBuffer(body)
+			assert.Equal(t, client.ErrInvalidServerSignature, verify(flipped, r))
+
+			// response replayed for a different request.
+			other := httptest.NewRequest("POST", "/transfers?to=mallory", nil)
+			other.Header.Set(client.NonceHeader, "nonce-1")
+			assert.Equal(t, client.ErrInvalidServerSignature, verify(w, other))
+
+			other = httptest.NewRequest("POST", "/transfers?to=alice", nil)
+			other.Header.Set(client.NonceHeader, "nonce-2")
+			assert.Equal(t, client.ErrInvalidServerSignature, verify(w, other))
+
+			// unauthenticated request response signed as well.
+			w, r = serve("/transfers", "", "nonce-3")
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.NoError(t, verify(w, r))
+
+			// status changed from 401 to 200.
+			w.Code = http.StatusOK
+			assert.Equal(t, client.ErrInvalidServerSignature, verify(w, r))
+		})
+	}
+}
+
+func TestMutualAuthSignFailure(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Account-Balance", "100")
+		_, _ = w.Write([]byte("secret data"))
+	})
+	h := MutualAuth(failingSigner{}, strategy("user"), next)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer user")
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret data")
+	assert.Empty(t, w.Header().Get(Header))
+	assert.Empty(t, w.Header().Get("X-Account-Balance"))
+}
+
+// strategy authenticate requests with the matching bearer token.
+type strategy string
+
+func (s strategy) Authenticate(_ context.Context, r *http.Request) (auth.Info, error) {
+	if r.Header.Get("Authorization") != "Bearer "+string(s) {
+		return nil, errors.New("invalid credentials")
+	}
+	return auth.NewDefaultUser(string(s), "1", nil, nil), nil
+}
+
+type failingSigner struct{}
+
+func (failingSigner) Public() crypto.PublicKey { return nil }
+
+func (failingSigner) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("hsm unavailable")
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/shaj13/go-guardian/v2/auth"
+	"github.com/shaj13/go-guardian/v2/middleware/responder"
+)
+
+// SetResponder sets the responder used to reply to unauthenticated requests.
+// Default Value responder.TextResponder.
+func SetResponder(r responder.Responder) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*config); ok {
+			v.responder = r
+		}
+	})
+}
+
+// SetExemptions sets path patterns of requests that skip authentication,
+// and passed to the next handler directly, e.g health checks and metrics endpoints.
+// Patterns use path.Match syntax, a pattern ending with a slash or "*" matches the path prefix,
+// on a segment boundary, e.g "/metrics*" matches "/metrics" and "/metrics/go" but not "/metrics-admin".
+// The request path cleaned before matching, see path.Clean,
+// and the next handler receives the request with the cleaned path.
+func SetExemptions(patterns ...string) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if v, ok := v.(*config); ok {
+			v.exemptions = append(v.exemptions, patterns...)
+		}
+	})
+}
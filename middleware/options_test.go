@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/middleware/responder"
+)
+
+func TestSetResponder(t *testing.T) {
+	c := newConfig(SetResponder(responder.JSONResponder{}))
+	assert.Equal(t, responder.JSONResponder{}, c.responder)
+	assert.Equal(t, responder.TextResponder{}, newConfig().responder)
+}
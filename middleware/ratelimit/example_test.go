@@ -0,0 +1,53 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+
+	"github.com/shaj13/go-guardian/v2/middleware/ratelimit"
+)
+
+func ExampleNewSlidingWindow() {
+	limiter := ratelimit.NewSlidingWindow(1, time.Minute, libcache.LRU.New(0))
+
+	fmt.Println(limiter.Allow("user:example"))
+	fmt.Println(limiter.Allow("user:example"))
+
+	// Output:
+	// true
+	// false
+}
+
+func ExampleMiddleware() {
+	limiter := ratelimit.NewSlidingWindow(1, time.Minute, libcache.LRU.New(0))
+	handler := ratelimit.Middleware(limiter)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		handler.ServeHTTP(w, r)
+		fmt.Printf("%d %q\n", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	// Output:
+	// 200 ""
+	// 429 "60"
+}
+
+func ExampleNewTokenBucket() {
+	limiter := ratelimit.NewTokenBucket(1, 2, libcache.LRU.New(0))
+
+	fmt.Println(limiter.Consume("user:example", 2))
+	fmt.Println(limiter.Consume("user:example", 1))
+
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,15 @@
+package ratelimit
+
+import (
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SetKeyFunc sets the function used by Middleware to derive the rate limit key.
+// Default DefaultKey.
+func SetKeyFunc(fn KeyFunc) auth.Option {
+	return auth.OptionFunc(func(v interface{}) {
+		if h, ok := v.(*handler); ok {
+			h.key = fn
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package ratelimit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetKeyFunc(t *testing.T) {
+	h := new(handler)
+	opt := SetKeyFunc(func(r *http.Request) string { return "key" })
+	opt.Apply(h)
+	assert.Equal(t, "key", h.key(nil))
+}
@@ -0,0 +1,75 @@
+// Package ratelimit provides rate limiters keyed by the authenticated identity,
+// or the client IP for unauthenticated requests,
+// to protect authentication endpoints from credential stuffing and abusive clients.
+package ratelimit
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// Limiter represents a rate limiter keyed by an identity.
+type Limiter interface {
+	// Allow reports whether a request for the given key may happen now,
+	// and records it.
+	Allow(key string) bool
+	// RetryAfter returns the duration until a request for the given key allowed.
+	RetryAfter(key string) time.Duration
+}
+
+// KeyFunc declare function signature to derive the rate limit key from HTTP request.
+type KeyFunc func(r *http.Request) string
+
+// DefaultKey return the authenticated user name stored in the request context,
+// Otherwise, the request remote IP.
+func DefaultKey(r *http.Request) string {
+	if info := auth.User(r); info != nil {
+		return "user:" + info.GetUserName()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// Middleware return HTTP middleware, that reject requests exceeding the limiter rate,
+// with 429 Too Many Requests and Retry-After header.
+//
+// Middleware must be placed after the authentication middleware,
+// to key requests on the authenticated user.
+func Middleware(l Limiter, opts ...auth.Option) func(http.Handler) http.Handler {
+	h := new(handler)
+	h.limiter = l
+	h.key = DefaultKey
+
+	for _, opt := range opts {
+		opt.Apply(h)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := h.key(r)
+			if !h.limiter.Allow(key) {
+				secs := math.Ceil(h.limiter.RetryAfter(key).Seconds())
+				w.Header().Set("Retry-After", strconv.Itoa(int(secs)))
+				code := http.StatusTooManyRequests
+				http.Error(w, http.StatusText(code), code)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+type handler struct {
+	limiter Limiter
+	key     KeyFunc
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+func TestDefaultKey(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ip:10.0.0.1", DefaultKey(r))
+
+	r = auth.RequestWithUser(auth.NewDefaultUser("test", "1", nil, nil), r)
+	assert.Equal(t, "user:test", DefaultKey(r))
+}
+
+func TestMiddleware(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSlidingWindow(2, time.Minute, libcache.LRU.New(0))
+	s.now = func() time.Time { return now }
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := Middleware(s)(next)
+
+	serve := func(addr string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = addr
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve("10.0.0.1:1").Code)
+	now = now.Add(time.Millisecond * 1500)
+	assert.Equal(t, http.StatusOK, serve("10.0.0.1:2").Code)
+
+	w := serve("10.0.0.1:3")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "59", w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, serve("10.0.0.2:1").Code)
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// SlidingWindow limit each key to at most limit requests within any window duration.
+// SlidingWindow records requests timestamps per key, in a cache that evicts inactive keys with the window TTL.
+type SlidingWindow struct {
+	limit  int
+	window time.Duration
+	cache  auth.Cache
+	mu     sync.Mutex
+	now    func() time.Time
+}
+
+// Allow reports whether a request for the given key may happen now.
+func (s *SlidingWindow) Allow(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	hits := s.hits(key, now)
+
+	if len(hits) >= s.limit {
+		return false
+	}
+
+	hits = append(hits, now)
+	s.cache.StoreWithTTL(key, hits, s.window)
+	return true
+}
+
+// RetryAfter returns the duration until a request for the given key allowed.
+func (s *SlidingWindow) RetryAfter(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	hits := s.hits(key, now)
+
+	if len(hits) < s.limit {
+		return 0
+	}
+
+	return hits[len(hits)-s.limit].Add(s.window).Sub(now)
+}
+
+// hits return key requests timestamps within the window.
+func (s *SlidingWindow) hits(key string, now time.Time) []time.Time {
+	v, ok := s.cache.Load(key)
+	if !ok {
+		return nil
+	}
+
+	hits, _ := v.([]time.Time)
+	start := now.Add(-s.window)
+
+	for i, t := range hits {
+		if t.After(start) {
+			return hits[i:]
+		}
+	}
+
+	return nil
+}
+
+// NewSlidingWindow return sliding window rate limiter,
+// that allow limit requests per key within window duration.
+// NewSlidingWindow panics if limit or window is not positive.
+func NewSlidingWindow(limit int, window time.Duration, c auth.Cache) *SlidingWindow {
+	if limit <= 0 || window <= 0 {
+		panic(fmt.Sprintf("middleware/ratelimit: invalid sliding window limit %d or window %s", limit, window))
+	}
+
+	s := new(SlidingWindow)
+	s.limit = limit
+	s.window = window
+	s.cache = c
+	s.now = time.Now
+	return s
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingWindow(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSlidingWindow(3, time.Minute, libcache.LRU.New(0))
+	s.now = func() time.Time { return now }
+
+	// Round #1 burst traffic beyond limit rejected.
+	for i := 0; i < 3; i++ {
+		assert.True(t, s.Allow("key"))
+		now = now.Add(time.Second * 10)
+	}
+
+	assert.False(t, s.Allow("key"))
+	assert.True(t, s.Allow("other"), "limit must be per key")
+	assert.Equal(t, time.Second*30, s.RetryAfter("key"))
+
+	// Round #2 window roll only releases the oldest request.
+	now = now.Add(time.Second * 30)
+	assert.Equal(t, time.Duration(0), s.RetryAfter("key"))
+	assert.True(t, s.Allow("key"))
+	assert.False(t, s.Allow("key"))
+	assert.Equal(t, time.Second*10, s.RetryAfter("key"))
+
+	// Round #3 a full window of inactivity reset the key.
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.True(t, s.Allow("key"))
+	}
+	assert.False(t, s.Allow("key"))
+}
+
+func TestSlidingWindowBoundary(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSlidingWindow(1, time.Second, libcache.LRU.New(0))
+	s.now = func() time.Time { return now }
+
+	assert.True(t, s.Allow("key"))
+
+	now = now.Add(time.Second - time.Nanosecond)
+	assert.False(t, s.Allow("key"))
+
+	now = now.Add(time.Nanosecond)
+	assert.True(t, s.Allow("key"))
+}
+
+func TestNewSlidingWindowInvalid(t *testing.T) {
+	assert.Panics(t, func() { NewSlidingWindow(0, time.Minute, libcache.LRU.New(0)) })
+	assert.Panics(t, func() { NewSlidingWindow(-1, time.Minute, libcache.LRU.New(0)) })
+	assert.Panics(t, func() { NewSlidingWindow(1, 0, libcache.LRU.New(0)) })
+}
@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/shaj13/go-guardian/v2/auth"
+)
+
+// TokenBucket allow each key bursts up to burst requests,
+// and refill the key bucket at rate tokens per second.
+// TokenBucket stores buckets state in a cache, buckets evicted once they are full again.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+	cache auth.Cache
+	mu    sync.Mutex
+	now   func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Allow reports whether a request for the given key may happen now.
+// Allow is shorthand for Consume(key, 1).
+func (tb *TokenBucket) Allow(key string) bool {
+	return tb.Consume(key, 1)
+}
+
+// Consume refill the key bucket and deduct n tokens from it,
+// Consume reports false without deducting tokens, when the bucket has less than n tokens,
+// or n is not positive.
+func (tb *TokenBucket) Consume(key string, n int) bool {
+	if n <= 0 {
+		return false
+	}
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	b := tb.refill(key)
+	if b.tokens < float64(n) {
+		return false
+	}
+
+	b.tokens -= float64(n)
+	tb.cache.StoreWithTTL(key, b, tb.ttl(b))
+	return true
+}
+
+// Remaining returns the available tokens in the key bucket.
+func (tb *TokenBucket) Remaining(key string) float64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.refill(key).tokens
+}
+
+// RetryAfter returns the duration until the key bucket has a token.
+func (tb *TokenBucket) RetryAfter(key string) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	b := tb.refill(key)
+	if b.tokens >= 1 {
+		return 0
+	}
+
+	return secs((1 - b.tokens) / tb.rate)
+}
+
+// refill return the key bucket refilled with tokens earned since the last update.
+func (tb *TokenBucket) refill(key string) bucket {
+	now := tb.now()
+	b := bucket{tokens: tb.burst, last: now}
+
+	if v, ok := tb.cache.Load(key); ok {
+		if cached, ok := v.(bucket); ok {
+			b = cached
+		}
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * tb.rate
+	}
+
+	b.tokens = math.Min(tb.burst, b.tokens)
+
+	b.last = now
+	return b
+}
+
+// ttl return the duration until the bucket is full,
+// at which point its state is equal to a fresh bucket.
+func (tb *TokenBucket) ttl(b bucket) time.Duration {
+	return secs((tb.burst-b.tokens)/tb.rate) + time.Second
+}
+
+func secs(f float64) time.Duration {
+	return time.Duration(f * float64(time.Second))
+}
+
+// NewTokenBucket return token bucket rate limiter,
+// that refill rate tokens per second up to burst tokens.
+// NewTokenBucket panics if rate or burst is not positive.
+func NewTokenBucket(rate float64, burst int, c auth.Cache) *TokenBucket {
+	if !(rate > 0) || math.IsInf(rate, 1) || burst <= 0 {
+		panic(fmt.Sprintf("middleware/ratelimit: invalid token bucket rate %v or burst %d", rate, burst))
+	}
+
+	tb := new(TokenBucket)
+	tb.rate = rate
+	tb.burst = float64(burst)
+	tb.cache = c
+	tb.now = time.Now
+	return tb
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shaj13/libcache"
+	_ "github.com/shaj13/libcache/lru"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tb := NewTokenBucket(2, 4, libcache.LRU.New(0))
+	tb.now = func() time.Time { return now }
+
+	// Round #1 new key start with a full bucket.
+	assert.Equal(t, float64(4), tb.Remaining("key"))
+	assert.True(t, tb.Consume("key", 3))
+	assert.False(t, tb.Consume("key", 2), "consume must not deduct partially")
+	assert.InDelta(t, 1, tb.Remaining("key"), 1e-9)
+
+	// Round #2 refill at sub-second granularity.
+	now = now.Add(time.Millisecond * 250)
+	assert.InDelta(t, 1.5, tb.Remaining("key"), 1e-9)
+
+	now = now.Add(time.Millisecond * 100)
+	assert.InDelta(t, 1.7, tb.Remaining("key"), 1e-9)
+
+	assert.True(t, tb.Allow("key"))
+	assert.InDelta(t, 0.7, tb.Remaining("key"), 1e-9)
+	assert.False(t, tb.Allow("key"))
+	assert.Equal(t, time.Millisecond*150, tb.RetryAfter("key").Round(time.Millisecond))
+
+	// Round #3 refill capped at burst.
+	now = now.Add(time.Hour)
+	assert.Equal(t, float64(4), tb.Remaining("key"))
+	assert.Equal(t, time.Duration(0), tb.RetryAfter("key"))
+	assert.Equal(t, float64(4), tb.Remaining("other"), "buckets must be per key")
+
+	// Round #4 non positive consume rejected without minting tokens.
+	assert.False(t, tb.Consume("key", 0))
+	assert.False(t, tb.Consume("key", -10))
+	assert.Equal(t, float64(4), tb.Remaining("key"))
+
+	// Round #5 tokens capped at burst when the clock goes backwards.
+	tb.cache.Store("key", bucket{tokens: 10, last: now})
+	now = now.Add(-time.Second)
+	assert.Equal(t, float64(4), tb.Remaining("key"))
+}
+
+func TestNewTokenBucketInvalid(t *testing.T) {
+	assert.Panics(t, func() { NewTokenBucket(0, 1, libcache.LRU.New(0)) })
+	assert.Panics(t, func() { NewTokenBucket(-1, 1, libcache.LRU.New(0)) })
+	assert.Panics(t, func() { NewTokenBucket(math.NaN(), 1, libcache.LRU.New(0)) })
+	assert.Panics(t, func() { NewTokenBucket(1, 0, libcache.LRU.New(0)) })
+}
@@ -0,0 +1,30 @@
+package responder_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/shaj13/go-guardian/v2/middleware/responder"
+)
+
+func ExampleJSONResponder() {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	responder.JSONResponder{}.Respond(w, r, http.StatusUnauthorized, errors.New("invalid token"))
+	fmt.Print(w.Code, " ", w.Body.String())
+
+	// Output:
+	// 401 {"code":"UNAUTHORIZED","message":"Unauthorized"}
+}
+
+func ExampleRFC7235Responder() {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	responder.RFC7235Responder{Challenge: `Bearer realm="api"`}.Respond(w, r, http.StatusUnauthorized, nil)
+	fmt.Println(w.Code, w.Header().Get("WWW-Authenticate"))
+
+	// Output:
+	// 401 Bearer realm="api"
+}
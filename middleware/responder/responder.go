@@ -0,0 +1,139 @@
+// Package responder provides the responses written by the middleware,
+// when a request fails authentication or authorization.
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Responder writes the failure response of a request.
+type Responder interface {
+	// Respond writes the response with the status code,
+	// err is the strategy error, and might be nil.
+	Respond(w http.ResponseWriter, r *http.Request, code int, err error)
+}
+
+// Func is an adapter to allow the use of ordinary functions as Responder.
+type Func func(w http.ResponseWriter, r *http.Request, code int, err error)
+
+// Respond calls fn(w, r, code, err).
+func (fn Func) Respond(w http.ResponseWriter, r *http.Request, code int, err error) {
+	fn(w, r, code, err)
+}
+
+// TextResponder replies with the plain text status text of the code.
+// It is the middleware default responder.
+type TextResponder struct{}
+
+// Respond implements Responder.
+func (TextResponder) Respond(w http.ResponseWriter, _ *http.Request, code int, _ error) {
+	http.Error(w, http.StatusText(code), code)
+}
+
+// JSONBody represents JSONResponder response body.
+type JSONBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSONResponder replies with a JSON body,
+// holding a machine-readable code derived from the status text e.g "UNAUTHORIZED",
+// and a human-readable message.
+type JSONResponder struct {
+	// Verbose use the strategy error as the message,
+	// Otherwise the status text used, to avoid leaking backend errors to clients.
+	Verbose bool
+}
+
+// Respond implements Responder.
+func (j JSONResponder) Respond(w http.ResponseWriter, _ *http.Request, code int, err error) {
+	text := http.StatusText(code)
+	body := JSONBody{
+		Code:    strings.ToUpper(strings.Replace(text, " ", "_", -1)),
+		Message: text,
+	}
+
+	if j.Verbose && err != nil {
+		body.Message = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// RFC7235Responder replies without a body,
+// and sets the WWW-Authenticate header challenge on 401 Unauthorized responses,
+// as defined in RFC 7235.
+type RFC7235Responder struct {
+	// Challenge is the WWW-Authenticate header value e.g `Bearer realm="api"`.
+	Challenge string
+}
+
+// Respond implements Responder.
+func (rfc RFC7235Responder) Respond(w http.ResponseWriter, _ *http.Request, code int, _ error) {
+	if code == http.StatusUnauthorized && len(rfc.Challenge) > 0 {
+		w.Header().Set("WWW-Authenticate", rfc.Challenge)
+	}
+	w.WriteHeader(code)
+}
+
+// NegotiatingResponder redirects browsers to the login page on 401 Unauthorized,
+// when the request Accept header include text/html,
+// with the original request URI in the "next" query parameter,
+// Otherwise, it delegates to the Fallback responder.
+type NegotiatingResponder struct {
+	// LoginURL is the login page URL e.g "/login".
+	LoginURL string
+	// Fallback responds to non-browser requests,
+	// Default replies with a JSON body e.g {"error":"unauthorized"}.
+	Fallback Responder
+}
+
+// Respond implements Responder.
+func (n NegotiatingResponder) Respond(w http.ResponseWriter, r *http.Request, code int, err error) {
+	if code == http.StatusUnauthorized && acceptsHTML(r) {
+		if u, perr := url.Parse(n.LoginURL); perr == nil {
+			q := u.Query()
+			q.Set("next", r.URL.RequestURI())
+			u.RawQuery = q.Encode()
+			http.Redirect(w, r, u.String(), http.StatusFound)
+			return
+		}
+	}
+
+	fallback := n.Fallback
+	if fallback == nil {
+		fallback = errorResponder{}
+	}
+
+	fallback.Respond(w, r, code, err)
+}
+
+// errorResponder replies with a JSON body holding the lower-cased status text,
+// e.g {"error":"unauthorized"}.
+type errorResponder struct{}
+
+func (errorResponder) Respond(w http.ResponseWriter, _ *http.Request, code int, _ error) {
+	text := strings.ToLower(strings.Replace(http.StatusText(code), " ", "_", -1))
+	body, _ := json.Marshal(map[string]string{"error": text})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func acceptsHTML(r *http.Request) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.Split(v, ";")[0])
+		if strings.EqualFold(mediaType, "text/html") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package responder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponders(t *testing.T) {
+	errBackend := errors.New("strategies/ldap: connection refused")
+
+	table := []struct {
+		name      string
+		responder Responder
+		code      int
+		header    http.Header
+		body      string
+	}{
+		{
+			name:      "TextResponder reply with status text",
+			responder: TextResponder{},
+			code:      http.StatusUnauthorized,
+			header: http.Header{
+				"Content-Type":           {"text/plain; charset=utf-8"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+			body: "Unauthorized\n",
+		},
+		{
+			name:      "JSONResponder reply with code and status text message",
+			responder: JSONResponder{},
+			code:      http.StatusUnauthorized,
+			header: http.Header{
+				"Content-Type":           {"application/json"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+			body: `{"code":"UNAUTHORIZED","message":"Unauthorized"}` + "\n",
+		},
+		{
+			name:      "JSONResponder reply with multi word code",
+			responder: JSONResponder{},
+			code:      http.StatusTooManyRequests,
+			header: http.Header{
+				"Content-Type":           {"application/json"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+			body: `{"code":"TOO_MANY_REQUESTS","message":"Too Many Requests"}` + "\n",
+		},
+		{
+			name:      "JSONResponder verbose reply with error message",
+			responder: JSONResponder{Verbose: true},
+			code:      http.StatusUnauthorized,
+			header: http.Header{
+				"Content-Type":           {"application/json"},
+				"X-Content-Type-Options": {"nosniff"},
+			},
+			body: `{"code":"UNAUTHORIZED","message":"strategies/ldap: connection refused"}` + "\n",
+		},
+		{
+			name:      "RFC7235Responder set WWW-Authenticate header only",
+			responder: RFC7235Responder{Challenge: `Bearer realm="api"`},
+			code:      http.StatusUnauthorized,
+			header: http.Header{
+				"Www-Authenticate": {`Bearer realm="api"`},
+			},
+		},
+		{
+			name:      "RFC7235Responder does not challenge forbidden requests",
+			responder: RFC7235Responder{Challenge: `Bearer realm="api"`},
+			code:      http.StatusForbidden,
+			header:    http.Header{},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/", nil)
+			tt.responder.Respond(w, r, tt.code, errBackend)
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.header, w.Header())
+			assert.Equal(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestFunc(t *testing.T) {
+	called := false
+	fn := Func(func(w http.ResponseWriter, r *http.Request, code int, err error) {
+		called = true
+		w.WriteHeader(code)
+	})
+
+	w := httptest.NewRecorder()
+	fn.Respond(w, nil, http.StatusForbidden, nil)
+	assert.True(t, called)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestNegotiatingResponder(t *testing.T) {
+	table := []struct {
+		name      string
+		responder NegotiatingResponder
+		accept    string
+		code      int
+		status    int
+		location  string
+		body      string
+	}{
+		{
+			name:      "it reply with json to api clients",
+			responder: NegotiatingResponder{LoginURL: "/login"},
+			accept:    "application/json",
+			code:      http.StatusUnauthorized,
+			status:    http.StatusUnauthorized,
+			body:      `{"error":"unauthorized"}`,
+		},
+		{
+			name:      "it reply with json when accept any",
+			responder: NegotiatingResponder{LoginURL: "/login"},
+			accept:    "*/*",
+			code:      http.StatusUnauthorized,
+			status:    http.StatusUnauthorized,
+			body:      `{"error":"unauthorized"}`,
+		},
+		{
+			name:      "it redirect browsers to login url with next",
+			responder: NegotiatingResponder{LoginURL: "/login"},
+			accept:    "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			code:      http.StatusUnauthorized,
+			status:    http.StatusFound,
+			location:  "/login?next=%2Foriginal%2Fpath%3Fpage%3D2",
+		},
+		{
+			name:      "it keep login url query",
+			responder: NegotiatingResponder{LoginURL: "https://sso.example.com/login?tenant=acme"},
+			accept:    "text/html",
+			code:      http.StatusUnauthorized,
+			status:    http.StatusFound,
+			location:  "https://sso.example.com/login?next=%2Foriginal%2Fpath%3Fpage%3D2&tenant=acme",
+		},
+		{
+			name:      "it does not redirect browsers on forbidden",
+			responder: NegotiatingResponder{LoginURL: "/login", Fallback: TextResponder{}},
+			accept:    "text/html",
+			code:      http.StatusForbidden,
+			status:    http.StatusForbidden,
+			body:      "Forbidden\n",
+		},
+		{
+			name:      "it reply with json error on forbidden by default",
+			responder: NegotiatingResponder{LoginURL: "/login"},
+			accept:    "text/html",
+			code:      http.StatusForbidden,
+			status:    http.StatusForbidden,
+			body:      `{"error":"forbidden"}`,
+		},
+		{
+			name:      "it reply with configured fallback",
+			responder: NegotiatingResponder{LoginURL: "/login", Fallback: JSONResponder{}},
+			accept:    "application/json",
+			code:      http.StatusUnauthorized,
+			status:    http.StatusUnauthorized,
+			body:      `{"code":"UNAUTHORIZED","message":"Unauthorized"}` + "\n",
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "/original/path?page=2", nil)
+			r.Header.Set("Accept", tt.accept)
+			tt.responder.Respond(w, r, tt.code, nil)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.location, w.Header().Get("Location"))
+			if len(tt.body) > 0 {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+			if len(tt.location) == 0 && tt.responder.Fallback == nil {
+				assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
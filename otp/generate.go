@@ -7,8 +7,8 @@ import (
 	"encoding/base32"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
-	"strconv"
 	"strings"
 )
 
@@ -20,8 +20,9 @@ var ErrWeakSecretSize = errors.New("Weak secret size, The shared secret MUST be
 // The function compliant with RFC 4226, and implemented as mentioned in section 5.3
 // See https://tools.ietf.org/html/rfc4226#section-5.3
 func GenerateOTP(secret string, counter uint64, algo HashAlgorithm, dig Digits) (string, error) {
-	secret = strings.ToUpper(secret)
-	key, err := base32.StdEncoding.DecodeString(secret)
+	// accept both padded and unpadded secrets, GenerateSecret returns unpadded secrets.
+	secret = strings.TrimRight(strings.ToUpper(secret), "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
 
 	if err != nil {
 		return "", err
@@ -56,7 +57,8 @@ func GenerateOTP(secret string, counter uint64, algo HashAlgorithm, dig Digits)
 	// signed bit removes all ambiguity.
 	code := int(binCode&0x7fffffff) % int(math.Pow10(int(dig)))
 
-	return strconv.Itoa(code), nil
+	// left pad the code with zeros to the required digits length.
+	return fmt.Sprintf("%0*d", int(dig), code), nil
 }
 
 // GenerateSecret return base32 random generated secret.
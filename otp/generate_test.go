@@ -1,6 +1,8 @@
 package otp
 
 import (
+	"encoding/base32"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +14,54 @@ func TestGenerateOTP(t *testing.T) {
 	assert.Equal(t, otp, "639434")
 }
 
+func TestGenerateOTPRFC6238(t *testing.T) {
+	// Test vectors from RFC 6238 Appendix B.
+	seed := func(n int) string {
+		b := []byte(strings.Repeat("1234567890", 7)[:n])
+		return base32.StdEncoding.EncodeToString(b)
+	}
+
+	table := []struct {
+		time  uint64
+		algo  HashAlgorithm
+		otp   string
+		seedn int
+	}{
+		{time: 59, algo: SHA1, otp: "94287082", seedn: 20},
+		{time: 59, algo: SHA256, otp: "46119246", seedn: 32},
+		{time: 59, algo: SHA512, otp: "90693936", seedn: 64},
+		{time: 1111111109, algo: SHA1, otp: "07081804", seedn: 20},
+		{time: 1111111109, algo: SHA256, otp: "68084774", seedn: 32},
+		{time: 1111111109, algo: SHA512, otp: "25091201", seedn: 64},
+		{time: 1111111111, algo: SHA1, otp: "14050471", seedn: 20},
+		{time: 1111111111, algo: SHA256, otp: "67062674", seedn: 32},
+		{time: 1111111111, algo: SHA512, otp: "99943326", seedn: 64},
+		{time: 1234567890, algo: SHA1, otp: "89005924", seedn: 20},
+		{time: 1234567890, algo: SHA256, otp: "91819424", seedn: 32},
+		{time: 1234567890, algo: SHA512, otp: "93441116", seedn: 64},
+		{time: 2000000000, algo: SHA1, otp: "69279037", seedn: 20},
+		{time: 2000000000, algo: SHA256, otp: "90698825", seedn: 32},
+		{time: 2000000000, algo: SHA512, otp: "38618901", seedn: 64},
+		{time: 20000000000, algo: SHA1, otp: "65353130", seedn: 20},
+		{time: 20000000000, algo: SHA256, otp: "77737706", seedn: 32},
+		{time: 20000000000, algo: SHA512, otp: "47863826", seedn: 64},
+	}
+
+	for _, tt := range table {
+		otp, err := GenerateOTP(seed(tt.seedn), tt.time/30, tt.algo, EightDigits)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.otp, otp, "time %d algo %s", tt.time, tt.algo)
+	}
+}
+
+func TestGenerateOTPUnpaddedSecret(t *testing.T) {
+	secret, err := GenerateSecret(16)
+	assert.NoError(t, err)
+
+	_, err = GenerateOTP(secret, 0, SHA1, SixDigits)
+	assert.NoError(t, err)
+}
+
 func TestGenerateSecret(t *testing.T) {
 	// Round #1 it return error when secretsize < 16
 	_, err := GenerateSecret(1)
@@ -122,3 +122,32 @@ func TestLockOutE2E(t *testing.T) {
 	err = v.lockOut()
 	assert.Contains(t, err.Error(), "Password verification disabled")
 }
+
+func TestVerifierTOTPSkew(t *testing.T) {
+	secret := "GXNRHI2MFRFWXQGJHWZJFOSYI6E7MEVA"
+	key := NewKey(TOTP, "LABEL", secret)
+	current := uint64(time.Now().UTC().Unix()) / key.Period()
+
+	table := []struct {
+		name  string
+		shift int64
+		valid bool
+	}{
+		{name: "current interval", shift: 0, valid: true},
+		{name: "previous interval within skew", shift: -1, valid: true},
+		{name: "next interval within skew", shift: 1, valid: true},
+		{name: "interval before skew window", shift: -3, valid: false},
+		{name: "interval after skew window", shift: 3, valid: false},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			v := New(key)
+			v.EnableLockout = false
+			code, _ := GenerateOTP(secret, uint64(int64(current)+tt.shift), SHA1, SixDigits)
+			ok, err := v.Verify(code)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.valid, ok)
+		})
+	}
+}